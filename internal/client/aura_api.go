@@ -19,23 +19,32 @@ package client
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/util"
 )
 
+// The request and response types, and the endpoint stubs the methods of AuraApi delegate to, are generated from
+// the OpenAPI specification of the Aura API in openapi/aura-v1.json.
+//go:generate go run ../openapigen -spec openapi/aura-v1.json -out aura_generated.go
+
 type AuraApi struct {
 	auraClient      *AuraClient
 	instanceTimeout time.Duration
 	snapshotTimeout time.Duration
+	poller          *instancePoller
 }
 
 const (
 	defaultInstanceTimeout = time.Duration(900) * time.Second
 	defaultSnapshotTimeout = time.Duration(300) * time.Second
+	maxWaitInterval        = time.Duration(15) * time.Second
 )
 
 func NewAuraApi(client *AuraClient, instanceTimeoutInSecs *int64, snapshotTimeoutInSecs *int64) *AuraApi {
@@ -48,176 +57,133 @@ func NewAuraApi(client *AuraClient, instanceTimeoutInSecs *int64, snapshotTimeou
 	if snapshotTimeoutInSecs != nil {
 		snapshotTimeout = time.Duration(*snapshotTimeoutInSecs) * time.Second
 	}
-	return &AuraApi{
+	api := &AuraApi{
 		auraClient:      client,
 		instanceTimeout: instanceTimeout,
 		snapshotTimeout: snapshotTimeout,
 	}
+	api.poller = newInstancePoller(api)
+	return api
 }
 
 func (api *AuraApi) GetTenants(ctx context.Context) (GetProjectsResponse, error) {
-	payload, status, err := api.auraClient.Get(ctx, "tenants")
-	if err != nil {
-		return GetProjectsResponse{}, err
-	}
-
-	if status != 200 {
-		return GetProjectsResponse{}, fmt.Errorf("aura error: Status: %+v. Response: %+v", status, string(payload))
-	}
+	return listTenants(ctx, api.auraClient)
+}
 
-	return util.Unmarshal[GetProjectsResponse](payload)
+// GetTenantById returns the tenant with the instance configurations available to it
+func (api *AuraApi) GetTenantById(ctx context.Context, id string) (GetTenantResponse, error) {
+	return getTenant(ctx, api.auraClient, id)
 }
 
 func (api *AuraApi) PostInstance(ctx context.Context, request PostInstanceRequest) (PostInstanceResponse, error) {
-	payload, err := json.Marshal(request)
-	if err != nil {
-		return PostInstanceResponse{}, err
-	}
-
-	body, status, err := api.auraClient.Post(ctx, "instances", payload)
-	if err != nil {
-		return PostInstanceResponse{}, err
-	}
+	return createInstance(withNonIdempotentRequest(ctx), api.auraClient, &request)
+}
 
-	if status != 202 {
-		return PostInstanceResponse{}, fmt.Errorf("aura error: Status: %+v. Response: %+v", status, string(body))
+// ListInstances returns the instances of the tenant, or of every tenant when tenantId is empty
+func (api *AuraApi) ListInstances(ctx context.Context, tenantId string) ([]InstanceSummary, error) {
+	path := "instances"
+	if tenantId != "" {
+		path += "?" + url.Values{"tenantId": {tenantId}}.Encode()
 	}
-
-	return util.Unmarshal[PostInstanceResponse](body)
+	return listAll[InstanceSummary](withOperation(ctx, "listInstances"), api.auraClient, path)
 }
 
 func (api *AuraApi) GetInstanceById(ctx context.Context, id string) (GetInstanceResponse, error) {
-	payload, status, err := api.auraClient.Get(ctx, "instances/"+id)
-	if err != nil {
-		return GetInstanceResponse{}, err
-	}
-	if status != 200 {
-		return GetInstanceResponse{}, fmt.Errorf("aura error: Status: %+v. Response: %+v", status, string(payload))
-	}
-	return util.Unmarshal[GetInstanceResponse](payload)
+	return getInstance(ctx, api.auraClient, id)
 }
 
 func (api *AuraApi) DeleteInstanceById(ctx context.Context, id string) (GetInstanceResponse, error) {
-	payload, status, err := api.auraClient.Delete(ctx, "instances/"+id)
-	if err != nil {
-		return GetInstanceResponse{}, err
-	}
-	if status != 202 {
-		return GetInstanceResponse{}, fmt.Errorf("aura error: Status: %+v. Response: %+v", status, string(payload))
-	}
-	return util.Unmarshal[GetInstanceResponse](payload)
+	defer api.poller.invalidate(id)
+	return deleteInstance(ctx, api.auraClient, id)
 }
 
 func (api *AuraApi) PatchInstanceById(ctx context.Context, id string, request PatchInstanceRequest) (GetInstanceResponse, error) {
-	payload, err := json.Marshal(request)
-	if err != nil {
-		return GetInstanceResponse{}, err
-	}
-
-	body, status, err := api.auraClient.Patch(ctx, "instances/"+id, payload)
-	if err != nil {
-		return GetInstanceResponse{}, err
-	}
-	if status != 202 {
-		return GetInstanceResponse{}, fmt.Errorf("aura error: Status: %+v. Response: %+v", status, string(body))
-	}
-	return util.Unmarshal[GetInstanceResponse](body)
+	defer api.poller.invalidate(id)
+	return updateInstance(ctx, api.auraClient, id, &request)
 }
 
 func (api *AuraApi) PauseInstanceById(ctx context.Context, id string) (GetInstanceResponse, error) {
-	body, status, err := api.auraClient.Post(ctx, fmt.Sprintf("instances/%s/pause", id), []byte("{}"))
-	if err != nil {
-		return GetInstanceResponse{}, err
-	}
-	if status != 202 {
-		return GetInstanceResponse{}, fmt.Errorf("aura error: Status: %+v. Response: %+v", status, string(body))
-	}
-	return util.Unmarshal[GetInstanceResponse](body)
+	defer api.poller.invalidate(id)
+	return pauseInstance(ctx, api.auraClient, id)
 }
 
 func (api *AuraApi) ResumeInstanceById(ctx context.Context, id string) (GetInstanceResponse, error) {
-	body, status, err := api.auraClient.Post(ctx, fmt.Sprintf("instances/%s/resume", id), []byte("{}"))
-	if err != nil {
-		return GetInstanceResponse{}, err
-	}
-	if status != 202 {
-		return GetInstanceResponse{}, fmt.Errorf("aura error: Status: %+v. Response: %+v", status, string(body))
-	}
-	return util.Unmarshal[GetInstanceResponse](body)
+	defer api.poller.invalidate(id)
+	return resumeInstance(ctx, api.auraClient, id)
+}
+
+// OverwriteInstance replaces the data of the instance with the data of another instance, or of one of its snapshots
+func (api *AuraApi) OverwriteInstance(ctx context.Context, id string, request OverwriteInstanceRequest) (GetInstanceResponse, error) {
+	defer api.poller.invalidate(id)
+	return overwriteInstance(withNonIdempotentRequest(ctx), api.auraClient, id, &request)
 }
 
 func (api *AuraApi) GetSnapshotsByInstanceId(ctx context.Context, instanceId string) (GetSnapshotsResponse, error) {
-	body, status, err := api.auraClient.Get(ctx, fmt.Sprintf("instances/%s/snapshots", instanceId))
-	if err != nil {
-		return GetSnapshotsResponse{}, err
-	}
-	if status != 200 {
-		return GetSnapshotsResponse{}, err
-	}
-	return util.Unmarshal[GetSnapshotsResponse](body)
+	return listSnapshots(ctx, api.auraClient, instanceId)
 }
 
 func (api *AuraApi) GetSnapshotById(ctx context.Context, instanceId string, snapshotId string) (GetSnapshotResponse, error) {
-	body, status, err := api.auraClient.Get(ctx, fmt.Sprintf("instances/%s/snapshots/%s", instanceId, snapshotId))
-	if err != nil {
-		return GetSnapshotResponse{}, err
-	}
-	if status != 200 {
-		return GetSnapshotResponse{}, err
-	}
-	return util.Unmarshal[GetSnapshotResponse](body)
+	return getSnapshot(ctx, api.auraClient, instanceId, snapshotId)
 }
 
 func (api *AuraApi) PostSnapshot(ctx context.Context, instanceId string) (PostSnapshotResponse, error) {
-	body, status, err := api.auraClient.Post(ctx, fmt.Sprintf("instances/%s/snapshots", instanceId), nil)
-	if err != nil {
-		return PostSnapshotResponse{}, err
-	}
-	if status != 202 {
-		return PostSnapshotResponse{}, fmt.Errorf("aura error: Status: %+v. Response: %+v", status, string(body))
-	}
-	return util.Unmarshal[PostSnapshotResponse](body)
+	return createSnapshot(withNonIdempotentRequest(ctx), api.auraClient, instanceId)
+}
+
+// RestoreSnapshot replaces the data of the instance with the data of one of its snapshots
+func (api *AuraApi) RestoreSnapshot(ctx context.Context, instanceId string, snapshotId string) (GetInstanceResponse, error) {
+	defer api.poller.invalidate(instanceId)
+	return restoreSnapshot(withNonIdempotentRequest(ctx), api.auraClient, instanceId, snapshotId)
 }
 
-func (api *AuraApi) WaitUntilSnapshotIsInState(
-	ctx context.Context, instanceId string, snapshotId string,
-	condition func(data GetSnapshotData) bool) (GetSnapshotData, error) {
+// WaitUntilSnapshotHasStatus waits until the snapshot has the given status. It stops as soon as
+// the snapshot is in a status from which the requested one can no longer be reached.
+func (api *AuraApi) WaitUntilSnapshotHasStatus(
+	ctx context.Context, instanceId string, snapshotId string, status string) (data GetSnapshotData, err error) {
 
+	ctx, finish := api.auraClient.telemetry.startWait(ctx, "snapshot", snapshotId)
+	defer func() { finish(err) }()
 	return util.WaitUntil(
-		func() (GetSnapshotData, error) {
+		ctx,
+		func(ctx context.Context) (GetSnapshotData, error) {
 			r, e := api.GetSnapshotById(ctx, instanceId, snapshotId)
 			tflog.Debug(ctx, fmt.Sprintf("Received response %+v and error %+v", r, e))
 			if e != nil {
-				return GetSnapshotData{}, e
+				return GetSnapshotData{}, stopWaitingOnInvalidCredentials(e)
 			}
-			return r.Data, e
+			if !domain.SnapshotStatusCanReach(r.Data.Status, status) {
+				return r.Data, util.StopWaiting(fmt.Errorf("snapshot %s of instance %s has status %q and can no longer become %q",
+					snapshotId, instanceId, r.Data.Status, status))
+			}
+			return r.Data, nil
 		},
 		func(resp GetSnapshotData, e error) bool {
-			return e == nil && condition(resp)
+			return e == nil && strings.EqualFold(resp.Status, status)
 		},
-		time.Second,
-		api.snapshotTimeout,
+		waitOptions(time.Second, api.snapshotTimeout),
 	)
 }
 
 func (api *AuraApi) WaitUntilSnapshotsMatchCondition(
 	ctx context.Context, instanceId string,
-	condition func(data GetSnapshotsResponse) bool) (GetSnapshotsResponse, error) {
+	condition func(data GetSnapshotsResponse) bool) (resp GetSnapshotsResponse, err error) {
 
+	ctx, finish := api.auraClient.telemetry.startWait(ctx, "snapshots", instanceId)
+	defer func() { finish(err) }()
 	return util.WaitUntil(
-		func() (GetSnapshotsResponse, error) {
+		ctx,
+		func(ctx context.Context) (GetSnapshotsResponse, error) {
 			r, e := api.GetSnapshotsByInstanceId(ctx, instanceId)
 			tflog.Debug(ctx, fmt.Sprintf("Received response %+v and error %+v", r, e))
 			if e != nil {
-				return GetSnapshotsResponse{}, e
+				return GetSnapshotsResponse{}, stopWaitingOnInvalidCredentials(e)
 			}
 			return r, e
 		},
 		func(resp GetSnapshotsResponse, e error) bool {
 			return e == nil && condition(resp)
 		},
-		time.Second,
-		api.snapshotTimeout,
+		waitOptions(time.Second, api.snapshotTimeout),
 	)
 }
 
@@ -225,32 +191,102 @@ func (api *AuraApi) WaitUntilInstanceIsInState(
 	ctx context.Context,
 	id string,
 	condition func(GetInstanceResponse) bool) (GetInstanceResponse, error) {
+
+	return api.waitUntilInstance(ctx, id, condition, func(resp GetInstanceResponse) error {
+		if domain.IsInstanceStatusFailure(resp.Data.Status) {
+			return fmt.Errorf("instance %s has failed with status %q", id, resp.Data.Status)
+		}
+		return nil
+	})
+}
+
+// WaitUntilInstanceHasStatus waits until the instance has the given status. It stops as soon as
+// the instance is in a status from which the requested one can no longer be reached.
+func (api *AuraApi) WaitUntilInstanceHasStatus(ctx context.Context, id string, status string) (GetInstanceResponse, error) {
+	return api.waitUntilInstance(ctx, id,
+		func(resp GetInstanceResponse) bool {
+			return strings.EqualFold(resp.Data.Status, status)
+		},
+		func(resp GetInstanceResponse) error {
+			if !domain.InstanceStatusCanReach(resp.Data.Status, status) {
+				return fmt.Errorf("instance %s has status %q and can no longer become %q", id, resp.Data.Status, status)
+			}
+			return nil
+		})
+}
+
+// waitUntilInstance waits until condition is satisfied, or until unreachable reports that it never will be
+func (api *AuraApi) waitUntilInstance(
+	ctx context.Context,
+	id string,
+	condition func(GetInstanceResponse) bool,
+	unreachable func(GetInstanceResponse) error) (resp GetInstanceResponse, err error) {
+
+	ctx, finish := api.auraClient.telemetry.startWait(ctx, "instance", id)
+	defer func() { finish(err) }()
 	return util.WaitUntil(
-		func() (GetInstanceResponse, error) {
-			resp, err := api.GetInstanceById(ctx, id)
+		ctx,
+		func(ctx context.Context) (GetInstanceResponse, error) {
+			resp, err := api.poller.instance(ctx, id)
 			tflog.Trace(ctx, fmt.Sprintf("Received response %+v and error %+v", resp, err))
-			return resp, err
+			if err != nil {
+				return resp, stopWaitingOnInvalidCredentials(err)
+			}
+			if condition(resp) {
+				return resp, nil
+			}
+			if err := unreachable(resp); err != nil {
+				return resp, util.StopWaiting(err)
+			}
+			return resp, nil
 		},
 		func(resp GetInstanceResponse, e error) bool {
 			return e == nil && condition(resp)
 		},
-		time.Second,
-		api.instanceTimeout,
+		waitOptions(time.Second, api.instanceTimeout),
 	)
 }
 
+// WaitUntilInstanceIsDeleted waits until the instance no longer exists
 func (api *AuraApi) WaitUntilInstanceIsDeleted(ctx context.Context, id string) (err error) {
+	ctx, finish := api.auraClient.telemetry.startWait(ctx, "instance deletion", id)
+	defer func() { finish(err) }()
+
+	var tenantId string
+	stopWaiting := func() {}
+	defer func() { stopWaiting() }()
 	_, err = util.WaitUntil(
-		func() (status int, err error) {
-			_, status, err = api.auraClient.Get(ctx, "instances/"+id)
-			tflog.Trace(ctx, fmt.Sprintf("Received response status %+d and error %+v", status, err))
-			return
+		ctx,
+		func(ctx context.Context) (bool, error) {
+			deleted, polledTenantId, err := api.poller.isDeleted(ctx, id, tenantId)
+			if tenantId == "" && polledTenantId != "" {
+				tenantId = polledTenantId
+				stopWaiting = api.poller.waitDeletion(tenantId)
+			}
+			tflog.Trace(ctx, fmt.Sprintf("Instance %s deleted: %t, error: %+v", id, deleted, err))
+			return deleted, stopWaitingOnInvalidCredentials(err)
 		},
-		func(status int, err error) bool {
-			return err == nil && status == 404
+		func(deleted bool, err error) bool {
+			return err == nil && deleted
 		},
-		time.Millisecond*500,
-		api.instanceTimeout,
+		waitOptions(time.Millisecond*500, api.instanceTimeout),
 	)
-	return
+	return err
+}
+
+// stopWaitingOnInvalidCredentials aborts waiting when Aura rejects the credentials, as polling again can't succeed
+func stopWaitingOnInvalidCredentials(err error) error {
+	var invalidCredentials *InvalidCredentialsError
+	if errors.As(err, &invalidCredentials) {
+		return util.StopWaiting(err)
+	}
+	return err
+}
+
+func waitOptions(initialInterval time.Duration, timeout time.Duration) util.WaitOptions {
+	return util.WaitOptions{
+		InitialInterval: initialInterval,
+		MaxInterval:     maxWaitInterval,
+		Timeout:         timeout,
+	}
 }
@@ -22,10 +22,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const expirationBuffer = 60
@@ -33,10 +35,16 @@ const expirationBuffer = 60
 type AuraAuth struct {
 	clientId     string
 	clientSecret string
+	accessToken  string
 	mutex        *sync.Mutex
 	token        *AuraAuthToken
 	httpClient   *retryablehttp.Client
 	userAgent    string
+	baseUrl      string
+	tokenCache   *tokenCache
+	timeout      time.Duration
+	// rejectedToken was refused by Aura, so it mustn't be reused from the token cache
+	rejectedToken string
 }
 
 type AuraAuthToken struct {
@@ -45,7 +53,9 @@ type AuraAuthToken struct {
 }
 
 func (a *AuraAuth) authenticate(ctx context.Context) error {
-	authUrl := fmt.Sprintf("%s/%s", auraBasePath, "oauth/token")
+	authUrl := fmt.Sprintf("%s/%s", a.baseUrl, "oauth/token")
+	ctx, cancel := withTimeout(ctx, a.timeout)
+	defer cancel()
 	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", authUrl, []byte("grant_type=client_credentials"))
 	if err != nil {
 		return err
@@ -66,6 +76,9 @@ func (a *AuraAuth) authenticate(ctx context.Context) error {
 	}
 
 	//Check response status
+	if resp.StatusCode == http.StatusUnauthorized {
+		return &InvalidCredentialsError{Err: fmt.Errorf("authentication failed with status %d", resp.StatusCode)}
+	}
 	if resp.StatusCode != 200 {
 		return fmt.Errorf("authentication failed with status %d.  Check client id and secret values",
 			resp.StatusCode)
@@ -90,14 +103,71 @@ func (a *AuraAuth) authenticate(ctx context.Context) error {
 	return nil
 }
 
+func (t *AuraAuthToken) isValid() bool {
+	return t != nil && t.expiringAt > time.Now().Unix()+expirationBuffer
+}
+
 func (a *AuraAuth) GetToken(ctx context.Context) (string, error) {
+	if a.accessToken != "" {
+		return a.accessToken, nil
+	}
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
-	if a.token == nil || a.token.expiringAt <= time.Now().Unix()+expirationBuffer {
-		err := a.authenticate(ctx)
+	if !a.token.isValid() {
+		var err error
+		if a.tokenCache != nil {
+			err = a.authenticateWithCache(ctx)
+		} else {
+			err = a.authenticate(ctx)
+		}
 		if err != nil {
 			return "", err
 		}
 	}
 	return a.token.token, nil
 }
+
+// authenticateWithCache reuses a token stored by another provider process, or authenticates and stores the new token.
+// The cache is only an optimisation, so cache failures fall back to authenticating without it.
+func (a *AuraAuth) authenticateWithCache(ctx context.Context) error {
+	path := a.tokenCache.path(a.baseUrl, a.clientId, a.clientSecret)
+	unlock, err := a.tokenCache.lock(path)
+	if err != nil {
+		tflog.Warn(ctx, "Cannot lock the token cache, authenticating without it", map[string]interface{}{"error": err.Error()})
+		return a.authenticate(ctx)
+	}
+	defer unlock()
+
+	cached, err := a.tokenCache.load(path)
+	if err != nil {
+		tflog.Warn(ctx, "Cannot read the token cache", map[string]interface{}{"error": err.Error()})
+	}
+	if cached.isValid() && cached.token != a.rejectedToken {
+		tflog.Debug(ctx, "Reusing the cached Aura API token")
+		a.token = cached
+		return nil
+	}
+
+	if err := a.authenticate(ctx); err != nil {
+		return err
+	}
+	if err := a.tokenCache.store(path, a.token); err != nil {
+		tflog.Warn(ctx, "Cannot write the token cache", map[string]interface{}{"error": err.Error()})
+	}
+	return nil
+}
+
+// canReauthenticate reports whether a new token can be requested when Aura rejects the current one
+func (a *AuraAuth) canReauthenticate() bool {
+	return a.accessToken == ""
+}
+
+// invalidate discards the token after Aura rejected it, unless it was already replaced by another request
+func (a *AuraAuth) invalidate(token string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.rejectedToken = token
+	if a.token != nil && a.token.token == token {
+		a.token = nil
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/util"
+)
+
+// noBody is the request type of the endpoints that don't take a body
+type noBody struct{}
+
+// call sends a request to the Aura API and decodes its response. A nil body sends no payload. A response with a
+// status other than expectedStatuses is returned as an *AuraError, carrying the request id Aura assigned to it.
+func call[Req any, Resp any](ctx context.Context, c *AuraClient, method string, path string, body *Req, expectedStatuses ...int) (Resp, error) {
+	var zero Resp
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return zero, err
+		}
+	}
+
+	resp, err := c.do(ctx, method, path, payload)
+	if err != nil {
+		return zero, err
+	}
+	if !slices.Contains(expectedStatuses, resp.status) {
+		return zero, resp.error()
+	}
+	return util.Unmarshal[Resp](resp.body)
+}
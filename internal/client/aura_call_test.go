@@ -0,0 +1,92 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCallServer(t *testing.T) *AuraClient {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", tokenHandler)
+	mux.HandleFunc("POST /v1/instances/{id}/pause", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "{}" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"data":{"id":"instance-1","status":"pausing"}}`))
+	})
+	mux.HandleFunc("GET /v1/instances/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(requestIdHeader, "request-404")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"Instance not found"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	auraClient, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL})
+	require.NoError(t, err)
+	return auraClient
+}
+
+func TestCallDecodesExpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	auraClient := newCallServer(t)
+	resp, err := call[EmptyRequest, GetInstanceResponse](context.Background(), auraClient, http.MethodPost,
+		"instances/instance-1/pause", &EmptyRequest{}, http.StatusOK, http.StatusAccepted)
+	require.NoError(t, err)
+	assert.Equal(t, "instance-1", resp.Data.Id)
+	assert.Equal(t, "pausing", resp.Data.Status)
+}
+
+func TestCallReturnsTypedErrorWithRequestId(t *testing.T) {
+	t.Parallel()
+
+	auraClient := newCallServer(t)
+	_, err := call[noBody, GetInstanceResponse](context.Background(), auraClient, http.MethodGet,
+		"instances/missing", nil, http.StatusOK)
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+
+	var auraError *AuraError
+	require.True(t, errors.As(err, &auraError))
+	assert.Equal(t, "request-404", auraError.RequestId)
+	assert.Contains(t, err.Error(), "request id: request-404")
+}
+
+func TestCallRejectsUnexpectedSuccessStatus(t *testing.T) {
+	t.Parallel()
+
+	auraClient := newCallServer(t)
+	_, err := call[EmptyRequest, GetInstanceResponse](context.Background(), auraClient, http.MethodPost,
+		"instances/instance-1/pause", &EmptyRequest{}, http.StatusOK)
+	var auraError *AuraError
+	require.True(t, errors.As(err, &auraError))
+	assert.Equal(t, http.StatusAccepted, auraError.StatusCode)
+}
@@ -21,49 +21,162 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
-	auraBasePath = "https://api.neo4j.io"
-	auraV1Path   = auraBasePath + "/v1"
+	DefaultAuraBaseUrl = "https://api.neo4j.io"
+	auraV1Path         = "v1"
 )
 
 const (
-	maxRetries = 5
-	backoffMin = 1 * time.Second
-	backoffMax = 30 * time.Second
+	defaultMaxRetries = 5
+	defaultBackoffMin = 1 * time.Second
+	defaultBackoffMax = 30 * time.Second
 )
 
 type AuraClient struct {
 	auth       *AuraAuth
 	httpClient *retryablehttp.Client
 	userAgent  string
+	baseUrl    string
+	timeout    time.Duration
+	telemetry  *Telemetry
 }
 
-func NewAuraClient(clientId, clientSecret string, version string) *AuraClient {
+// AuraClientConfig configures an AuraClient. Nil or empty fields fall back to the defaults.
+type AuraClientConfig struct {
+	ClientId     string
+	ClientSecret string
+	// AccessToken is a pre-issued token, used instead of authenticating with the client id and secret
+	AccessToken  string
+	BaseUrl      string
+	Version      string
+	MaxRetries   *int
+	RetryWaitMin *time.Duration
+	RetryWaitMax *time.Duration
+	// TokenCacheDir enables the on-disk token cache in the given directory
+	TokenCacheDir string
+	// ProxyUrl overrides the proxy otherwise taken from the HTTPS_PROXY environment variable
+	ProxyUrl string
+	// CaCertFile is a PEM bundle of certificate authorities trusted in addition to the system ones
+	CaCertFile     string
+	ClientCertFile string
+	ClientKeyFile  string
+	// RequestTimeout bounds every HTTP attempt
+	RequestTimeout *time.Duration
+	// Timeout bounds a whole request, including its retries
+	Timeout *time.Duration
+	// CaptureFile enables the capture of the sanitized requests and responses to the given JSON Lines file
+	CaptureFile string
+	// MaxConcurrentRequests bounds the number of in-flight requests. Zero means no limit
+	MaxConcurrentRequests int
+	// RequestsPerSecond bounds the request rate. Zero means no limit
+	RequestsPerSecond float64
+	// Telemetry records the operations of the client, usually shared by the clients of the provider process
+	Telemetry *Telemetry
+}
+
+func NewAuraClient(config AuraClientConfig) (*AuraClient, error) {
+	baseUrl := config.BaseUrl
+	if baseUrl == "" {
+		baseUrl = DefaultAuraBaseUrl
+	}
+	baseUrl = strings.TrimRight(baseUrl, "/")
+
 	httpClient := retryablehttp.NewClient()
-	httpClient.RetryMax = maxRetries
-	httpClient.RetryWaitMin = backoffMin
-	httpClient.RetryWaitMax = backoffMax
-	httpClient.CheckRetry = retryablehttp.DefaultRetryPolicy
-	httpClient.Backoff = retryablehttp.DefaultBackoff
+	httpClient.RetryMax = defaultMaxRetries
+	if config.MaxRetries != nil {
+		httpClient.RetryMax = *config.MaxRetries
+	}
+	httpClient.RetryWaitMin = defaultBackoffMin
+	if config.RetryWaitMin != nil {
+		httpClient.RetryWaitMin = *config.RetryWaitMin
+	}
+	httpClient.RetryWaitMax = defaultBackoffMax
+	if config.RetryWaitMax != nil {
+		httpClient.RetryWaitMax = *config.RetryWaitMax
+	}
+	if config.RequestTimeout != nil {
+		httpClient.HTTPClient.Timeout = *config.RequestTimeout
+	}
+	transport := httpClient.HTTPClient.Transport.(*http.Transport)
+	if err := configureTransport(transport, config); err != nil {
+		return nil, err
+	}
+	logging := &loggingTransport{next: transport}
+	if config.CaptureFile != "" {
+		capture, err := openCaptureFile(config.CaptureFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open the capture file: %w", err)
+		}
+		logging.capture = capture
+	}
+	httpClient.HTTPClient.Transport = newLimitingTransport(logging, config.MaxConcurrentRequests, config.RequestsPerSecond)
+	// The logging transport logs every attempt, with the secrets masked
+	httpClient.Logger = nil
+	httpClient.RequestLogHook = countRetry
+	httpClient.CheckRetry = checkRetry
+	httpClient.Backoff = backoff
+	// Return the last response once retries are exhausted, so callers get a typed AuraError
+	httpClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	var timeout time.Duration
+	if config.Timeout != nil {
+		timeout = *config.Timeout
+	}
 
-	userAgent := fmt.Sprintf("AuraTerraform/v%s", version)
+	telemetry := config.Telemetry
+	if telemetry == nil {
+		telemetry = NewTelemetry()
+	}
+
+	var cache *tokenCache
+	if config.TokenCacheDir != "" {
+		cache = &tokenCache{dir: config.TokenCacheDir}
+	}
+
+	userAgent := fmt.Sprintf("AuraTerraform/v%s", config.Version)
 	return &AuraClient{
 		auth: &AuraAuth{
-			clientId:     clientId,
-			clientSecret: clientSecret,
+			clientId:     config.ClientId,
+			clientSecret: config.ClientSecret,
+			accessToken:  config.AccessToken,
 			httpClient:   httpClient,
 			mutex:        &sync.Mutex{},
 			userAgent:    userAgent,
+			baseUrl:      baseUrl,
+			tokenCache:   cache,
+			timeout:      timeout,
 		},
 		httpClient: httpClient,
 		userAgent:  userAgent,
-	}
+		baseUrl:    baseUrl,
+		timeout:    timeout,
+		telemetry:  telemetry,
+	}, nil
+}
+
+// auraResponse is a response of the Aura API, with the request id Aura assigned to the request
+type auraResponse struct {
+	body      []byte
+	status    int
+	requestId string
+}
+
+// error returns the typed error describing an unexpected response
+func (r auraResponse) error() *AuraError {
+	auraError := newAuraError(r.status, r.body)
+	auraError.RequestId = r.requestId
+	return auraError
 }
 
 func (c *AuraClient) Get(ctx context.Context, path string) ([]byte, int, error) {
@@ -83,16 +196,49 @@ func (c *AuraClient) Patch(ctx context.Context, path string, payload []byte) ([]
 }
 
 func (c *AuraClient) doOperation(ctx context.Context, method string, path string, payload []byte) ([]byte, int, error) {
-	token, err := c.auth.GetToken(ctx)
-	if err != nil {
-		return []byte{}, 0, err
-	}
+	resp, err := c.do(ctx, method, path, payload)
+	return resp.body, resp.status, err
+}
 
-	absoluteUrl := fmt.Sprintf("%s/%s", auraV1Path, path)
+func (c *AuraClient) do(ctx context.Context, method string, path string, payload []byte) (resp auraResponse, err error) {
+	absoluteUrl := fmt.Sprintf("%s/%s/%s", c.baseUrl, auraV1Path, path)
+	ctx, cancel := withTimeout(ctx, c.timeout)
+	defer cancel()
+	ctx, finish := c.telemetry.startOperation(ctx, method, path)
+	defer func() { finish(resp, err) }()
 
+	for attempt := 0; ; attempt++ {
+		token, err := c.auth.GetToken(ctx)
+		if err != nil {
+			return auraResponse{body: []byte{}}, err
+		}
+
+		resp, err := c.send(ctx, method, absoluteUrl, payload, token)
+		if err != nil || resp.status != http.StatusUnauthorized {
+			return resp, err
+		}
+		// Aura may revoke a token before its expiry, so authenticate again once before giving up
+		if attempt > 0 || !c.auth.canReauthenticate() {
+			return resp, &InvalidCredentialsError{Err: resp.error()}
+		}
+		tflog.Warn(ctx, "Aura API rejected the token, authenticating again")
+		c.auth.invalidate(token)
+	}
+}
+
+func (c *AuraClient) send(ctx context.Context, method string, absoluteUrl string, payload []byte, token string) (auraResponse, error) {
+	// Tells the failures before any attempt reached Aura from the ones Aura may have processed
+	var written atomic.Bool
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				written.Store(true)
+			}
+		},
+	})
 	req, err := retryablehttp.NewRequestWithContext(ctx, method, absoluteUrl, payload)
 	if err != nil {
-		return []byte{}, 0, err
+		return auraResponse{body: []byte{}}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
@@ -103,15 +249,18 @@ func (c *AuraClient) doOperation(ctx context.Context, method string, path string
 		defer resp.Body.Close()
 	}
 	if err != nil {
-		return []byte{}, 0, err
+		if written.Load() {
+			err = &unansweredError{err: err}
+		}
+		return auraResponse{body: []byte{}}, err
 	}
 	if resp == nil {
-		return []byte{}, 0, fmt.Errorf("no response from server")
+		return auraResponse{body: []byte{}}, fmt.Errorf("no response from server")
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return []byte{}, 0, err
+		return auraResponse{body: []byte{}}, &unansweredError{err: err}
 	}
-	return body, resp.StatusCode, nil
+	return auraResponse{body: body, status: resp.StatusCode, requestId: resp.Header.Get(requestIdHeader)}, nil
 }
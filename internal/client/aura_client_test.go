@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRevokingServer issues a new token on every authentication and rejects the first revokedTokens of them
+func newRevokingServer(t *testing.T, revokedTokens int32) (*httptest.Server, *atomic.Int32) {
+	var authentications atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		n := authentications.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":3600,"token_type":"bearer"}`, n)
+	})
+	mux.HandleFunc("GET /v1/tenants", func(w http.ResponseWriter, r *http.Request) {
+		for i := int32(1); i <= revokedTokens; i++ {
+			if r.Header.Get("Authorization") == fmt.Sprintf("Bearer token-%d", i) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"errors":[{"message":"Token is revoked","reason":"unauthorized"}]}`))
+				return
+			}
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &authentications
+}
+
+func TestDoOperationReauthenticatesOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server, authentications := newRevokingServer(t, 1)
+	auraClient, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL})
+	require.NoError(t, err)
+
+	_, status, err := auraClient.Get(context.Background(), "tenants")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int32(2), authentications.Load())
+}
+
+func TestDoOperationReportsInvalidCredentials(t *testing.T) {
+	t.Parallel()
+
+	server, authentications := newRevokingServer(t, 2)
+	auraClient, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL})
+	require.NoError(t, err)
+
+	_, _, err = auraClient.Get(context.Background(), "tenants")
+	var invalidCredentials *InvalidCredentialsError
+	require.ErrorAs(t, err, &invalidCredentials)
+	assert.True(t, IsUnauthorized(err))
+	assert.Contains(t, err.Error(), "Token is revoked")
+	assert.Equal(t, int32(2), authentications.Load())
+}
+
+func TestDoOperationDoesNotReauthenticateWithAccessToken(t *testing.T) {
+	t.Parallel()
+
+	server, authentications := newRevokingServer(t, 1)
+	auraClient, err := NewAuraClient(AuraClientConfig{AccessToken: "token-1", BaseUrl: server.URL})
+	require.NoError(t, err)
+
+	_, _, err = auraClient.Get(context.Background(), "tenants")
+	var invalidCredentials *InvalidCredentialsError
+	require.ErrorAs(t, err, &invalidCredentials)
+	assert.Equal(t, int32(0), authentications.Load())
+}
@@ -0,0 +1,157 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AuraError is returned by AuraApi when Aura responds with an unexpected status
+type AuraError struct {
+	StatusCode int
+	Errors     []AuraErrorDetail
+	Body       string
+	// RequestId identifies the request for Aura support, when Aura returned one
+	RequestId string
+}
+
+// AuraErrorDetail is a single entry of the errors array of an Aura error response
+type AuraErrorDetail struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	Field   string `json:"field"`
+}
+
+type auraErrorResponse struct {
+	Errors []AuraErrorDetail `json:"errors"`
+}
+
+func newAuraError(statusCode int, body []byte) *AuraError {
+	auraError := &AuraError{
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+	var response auraErrorResponse
+	if err := json.Unmarshal(body, &response); err == nil {
+		auraError.Errors = response.Errors
+	}
+	return auraError
+}
+
+func (e *AuraError) Error() string {
+	requestId := ""
+	if e.RequestId != "" {
+		requestId = fmt.Sprintf(" (request id: %s)", e.RequestId)
+	}
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("aura error: Status: %d. Response: %s%s", e.StatusCode, e.Body, requestId)
+	}
+	details := make([]string, len(e.Errors))
+	for i, d := range e.Errors {
+		details[i] = d.Message
+		if d.Reason != "" {
+			details[i] += fmt.Sprintf(" (reason: %s)", d.Reason)
+		}
+		if d.Field != "" {
+			details[i] += fmt.Sprintf(" (field: %s)", d.Field)
+		}
+	}
+	return fmt.Sprintf("aura error: Status: %d. %s%s", e.StatusCode, strings.Join(details, "; "), requestId)
+}
+
+// Message returns the message of the first error reported by Aura
+func (e *AuraError) Message() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+	return e.Errors[0].Message
+}
+
+// Reason returns the reason of the first error reported by Aura
+func (e *AuraError) Reason() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+	return e.Errors[0].Reason
+}
+
+// Field returns the request field of the first error reported by Aura
+func (e *AuraError) Field() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+	return e.Errors[0].Field
+}
+
+// InvalidCredentialsError is returned when Aura keeps rejecting the credentials, even after authenticating again
+type InvalidCredentialsError struct {
+	Err error
+}
+
+func (e *InvalidCredentialsError) Error() string {
+	return "Aura rejected the provider credentials. Check that the client id and secret, or the access token, " +
+		"are valid and haven't been revoked: " + e.Err.Error()
+}
+
+func (e *InvalidCredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// DiagnosticSummary replaces the summary of the diagnostic reporting the error
+func (e *InvalidCredentialsError) DiagnosticSummary() string {
+	return "Invalid Aura API credentials"
+}
+
+// unansweredError is the failure of a request sent to Aura without a response, e.g. a lost connection or a timeout
+// waiting for the response. Aura may have processed the request.
+type unansweredError struct {
+	err error
+}
+
+func (e *unansweredError) Error() string {
+	return e.err.Error()
+}
+
+func (e *unansweredError) Unwrap() error {
+	return e.err
+}
+
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var auraError *AuraError
+	return errors.As(err, &auraError) && auraError.StatusCode == statusCode
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuraError(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		status          int
+		body            string
+		expectedMessage string
+		expectedReason  string
+		expectedField   string
+		expectedError   string
+	}{
+		"aura error body": {
+			status:          400,
+			body:            `{"errors":[{"message":"Invalid memory","reason":"invalid-request","field":"memory"}]}`,
+			expectedMessage: "Invalid memory",
+			expectedReason:  "invalid-request",
+			expectedField:   "memory",
+			expectedError:   "aura error: Status: 400. Invalid memory (reason: invalid-request) (field: memory)",
+		},
+		"multiple errors": {
+			status:          409,
+			body:            `{"errors":[{"message":"First"},{"message":"Second","reason":"conflict"}]}`,
+			expectedMessage: "First",
+			expectedError:   "aura error: Status: 409. First; Second (reason: conflict)",
+		},
+		"non json body": {
+			status:        502,
+			body:          "Bad Gateway",
+			expectedError: "aura error: Status: 502. Response: Bad Gateway",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newAuraError(tc.status, []byte(tc.body))
+			assert.Equal(t, tc.status, err.StatusCode)
+			assert.Equal(t, tc.expectedMessage, err.Message())
+			assert.Equal(t, tc.expectedReason, err.Reason())
+			assert.Equal(t, tc.expectedField, err.Field())
+			assert.Equal(t, tc.expectedError, err.Error())
+		})
+	}
+}
+
+func TestAuraErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	wrapped := fmt.Errorf("while reading instance: %w", newAuraError(404, nil))
+	assert.True(t, IsNotFound(wrapped))
+	assert.False(t, IsConflict(wrapped))
+
+	assert.True(t, IsConflict(newAuraError(409, nil)))
+	assert.True(t, IsRateLimited(newAuraError(429, nil)))
+	assert.True(t, IsUnauthorized(newAuraError(401, nil)))
+	assert.False(t, IsNotFound(errors.New("aura error: Status: 404")))
+	assert.False(t, IsNotFound(nil))
+}
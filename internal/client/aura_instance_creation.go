@@ -0,0 +1,124 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// adoptionWindow is how recently an instance must have been created to be adopted after an ambiguous failure
+	adoptionWindow = 5 * time.Minute
+	// adoptionLookupTimeout bounds the lookup, which runs even when the context of the creation is done
+	adoptionLookupTimeout = time.Minute
+)
+
+// CreateInstance creates an instance without ever creating a duplicate. When the creation request fails in a way
+// that doesn't tell whether Aura processed it, the instances of the tenant are looked up: an instance created in the
+// last few minutes with the name, cloud provider, region, type and memory of the request is adopted. Otherwise the
+// request isn't sent again, as the list may not show the instance yet, and the error asks to check the Aura console.
+//
+// The password of an adopted instance is unknown, as Aura only returns it in the lost response.
+func (api *AuraApi) CreateInstance(ctx context.Context, request PostInstanceRequest) (PostInstanceResponse, bool, error) {
+	resp, err := api.PostInstance(ctx, request)
+	if err == nil || !isAmbiguousFailure(err) {
+		return resp, false, err
+	}
+
+	tflog.Warn(ctx, "Cannot tell whether Aura created the instance, looking it up",
+		map[string]interface{}{"name": request.Name, "error": err.Error()})
+	adopted, found, lookupErr := api.findRecentInstance(ctx, request)
+	if lookupErr != nil {
+		return PostInstanceResponse{}, false, fmt.Errorf("%w, and looking up the instance failed: %w", err, lookupErr)
+	}
+	if !found {
+		return PostInstanceResponse{}, false, fmt.Errorf("%w. Aura may still have created instance %q: check the "+
+			"Aura console and import the instance if it exists, or apply again otherwise", err, request.Name)
+	}
+	tflog.Warn(ctx, "Adopting the instance created by the failed request", map[string]interface{}{"id": adopted.Data.Id})
+	return adopted, true, nil
+}
+
+// findRecentInstance looks up the instance of the tenant created in the adoption window with the name, cloud
+// provider, region, type and memory of the request
+func (api *AuraApi) findRecentInstance(ctx context.Context, request PostInstanceRequest) (PostInstanceResponse, bool, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), adoptionLookupTimeout)
+	defer cancel()
+
+	summaries, err := api.ListInstances(ctx, request.TenantId)
+	if err != nil {
+		return PostInstanceResponse{}, false, err
+	}
+	var candidates []GetInstanceResponse
+	for _, summary := range summaries {
+		createdAt, err := summary.CreatedAtAsTime()
+		if summary.Name != request.Name || summary.CloudProvider != request.CloudProvider || err != nil ||
+			time.Since(createdAt) >= adoptionWindow {
+			continue
+		}
+		instance, err := api.GetInstanceById(ctx, summary.Id)
+		if IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return PostInstanceResponse{}, false, err
+		}
+		if instance.Data.Region != request.Region || instance.Data.Type != request.Type || instance.Data.Memory != request.Memory {
+			tflog.Debug(ctx, "Not adopting an instance with the same name but another configuration", map[string]interface{}{"id": summary.Id})
+			continue
+		}
+		candidates = append(candidates, instance)
+	}
+	switch len(candidates) {
+	case 0:
+		return PostInstanceResponse{}, false, nil
+	case 1:
+	default:
+		return PostInstanceResponse{}, false, fmt.Errorf("%d instances named %q were created in the last %s, import the right one",
+			len(candidates), request.Name, adoptionWindow)
+	}
+
+	instance := candidates[0]
+	return PostInstanceResponse{Data: PostInstanceData{
+		Id:            instance.Data.Id,
+		Name:          instance.Data.Name,
+		TenantId:      instance.Data.TenantId,
+		CloudProvider: instance.Data.CloudProvider,
+		ConnectionUrl: instance.Data.ConnectionUrl,
+		Region:        instance.Data.Region,
+		Type:          instance.Data.Type,
+	}}, true, nil
+}
+
+// isAmbiguousFailure reports whether Aura may have processed a request that failed: the request was sent but its
+// response was lost, or Aura failed while processing it. The failures before the request was sent, e.g. while
+// authenticating, aren't ambiguous.
+func isAmbiguousFailure(err error) bool {
+	var auraError *AuraError
+	if errors.As(err, &auraError) {
+		return auraError.StatusCode >= http.StatusInternalServerError
+	}
+	var unanswered *unansweredError
+	return errors.As(err, &unanswered)
+}
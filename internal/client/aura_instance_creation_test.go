@@ -0,0 +1,152 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/fakeaura"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPostInstanceRequest(name string) PostInstanceRequest {
+	return PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          name,
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      fakeaura.DefaultTenantId,
+	}
+}
+
+func TestCreateInstanceAdoptsInstanceOfLostResponse(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newFakeAuraApi(t, server)
+	ctx := context.Background()
+
+	server.DropNextCreationResponse()
+	created, adopted, err := api.CreateInstance(ctx, newPostInstanceRequest("adopted"))
+	require.NoError(t, err)
+	assert.True(t, adopted)
+	assert.Empty(t, created.Data.Password)
+	assert.NotEmpty(t, created.Data.ConnectionUrl)
+
+	instances, err := api.ListInstances(ctx, fakeaura.DefaultTenantId)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, instances[0].Id, created.Data.Id)
+	assert.Equal(t, 1, server.Requests("POST /v1/instances"))
+}
+
+func TestCreateInstanceDoesNotResendWhenNoInstanceIsFound(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newFakeAuraApi(t, server)
+	ctx := context.Background()
+
+	server.RejectNextCreation(http.StatusBadGateway)
+	_, adopted, err := api.CreateInstance(ctx, newPostInstanceRequest("not-created"))
+	assert.ErrorContains(t, err, "check the Aura console")
+	assert.False(t, adopted)
+	assert.Equal(t, 1, server.Requests("POST /v1/instances"))
+
+	instances, err := api.ListInstances(ctx, fakeaura.DefaultTenantId)
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+}
+
+func TestCreateInstanceOnlyAdoptsInstanceWithTheRequestedConfiguration(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newFakeAuraApi(t, server)
+	ctx := context.Background()
+
+	// Another workspace created an instance with the same name in another region
+	other := newPostInstanceRequest("shared-name")
+	other.Region = "europe-west2"
+	otherInstance, err := api.PostInstance(ctx, other)
+	require.NoError(t, err)
+
+	server.RejectNextCreation(http.StatusBadGateway)
+	_, adopted, err := api.CreateInstance(ctx, newPostInstanceRequest("shared-name"))
+	assert.ErrorContains(t, err, "check the Aura console")
+	assert.False(t, adopted)
+
+	server.DropNextCreationResponse()
+	created, adopted, err := api.CreateInstance(ctx, newPostInstanceRequest("shared-name"))
+	require.NoError(t, err)
+	assert.True(t, adopted)
+	assert.NotEqual(t, otherInstance.Data.Id, created.Data.Id)
+	assert.Equal(t, "europe-west1", created.Data.Region)
+}
+
+func TestCreateInstanceDoesNotLookUpAfterRejection(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newFakeAuraApi(t, server)
+
+	server.RejectNextCreation(http.StatusConflict)
+	_, adopted, err := api.CreateInstance(context.Background(), newPostInstanceRequest("rejected"))
+	assert.True(t, IsConflict(err))
+	assert.False(t, adopted)
+	assert.Equal(t, 1, server.Requests("POST /v1/instances"))
+	assert.Zero(t, server.Requests("GET /v1/instances"))
+}
+
+func TestCreateInstanceDoesNotLookUpWhenTheRequestIsNotSent(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer(fakeaura.WithCredentials("other-id", "other-secret"))
+	defer server.Close()
+	api := newFakeAuraApi(t, server)
+
+	// Authenticating fails before the creation request is sent
+	_, adopted, err := api.CreateInstance(context.Background(), newPostInstanceRequest("unauthenticated"))
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "check the Aura console")
+	assert.False(t, adopted)
+
+	assert.Zero(t, server.Requests("POST /v1/instances"))
+	assert.Zero(t, server.Requests("GET /v1/instances"))
+
+	// The context is done before the creation request is sent
+	server = fakeaura.NewServer()
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, adopted, err = newFakeAuraApi(t, server).CreateInstance(ctx, newPostInstanceRequest("canceled"))
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "check the Aura console")
+	assert.False(t, adopted)
+	assert.Zero(t, server.Requests("GET /v1/instances"))
+}
@@ -0,0 +1,193 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// instancePollTtl is how long a polled instance is shared with the other waiters
+	instancePollTtl = 500 * time.Millisecond
+	// listModeThreshold is the number of concurrent deletion waits of a tenant from which the instances of the tenant
+	// are listed, instead of being fetched one by one
+	listModeThreshold = 4
+	instanceIdsKey    = "instances/tenant/"
+)
+
+// instancePoller coalesces the polling of the waiters of AuraApi: identical concurrent GETs are sent once, and
+// their result is reused for instancePollTtl.
+//
+// The v1 list endpoint doesn't return the instance status, so only the deletion waits switch to a single
+// list call of their tenant per tick when many of them run concurrently in the same tenant.
+type instancePoller struct {
+	api   *AuraApi
+	group singleflight.Group
+
+	mutex     sync.Mutex
+	instances map[string]polled[GetInstanceResponse]
+	// instanceIds are the ids of the instances listed by tenant
+	instanceIds map[string]polled[map[string]bool]
+	// generation changes whenever an instance changes, so that responses fetched before aren't cached
+	generation uint64
+
+	// deletionWaiters is the number of deletion waits by tenant
+	deletionWaiters map[string]int
+}
+
+type polled[T any] struct {
+	value T
+	at    time.Time
+}
+
+func (p polled[T]) isFresh() bool {
+	return time.Since(p.at) < instancePollTtl
+}
+
+func newInstancePoller(api *AuraApi) *instancePoller {
+	return &instancePoller{
+		api:             api,
+		instances:       map[string]polled[GetInstanceResponse]{},
+		instanceIds:     map[string]polled[map[string]bool]{},
+		deletionWaiters: map[string]int{},
+	}
+}
+
+// instance returns the instance, sharing the response with the concurrent waiters of the same instance
+func (p *instancePoller) instance(ctx context.Context, id string) (GetInstanceResponse, error) {
+	p.mutex.Lock()
+	cached, found := p.instances[id]
+	p.mutex.Unlock()
+	if found && cached.isFresh() {
+		return cached.value, nil
+	}
+
+	return coalesce(ctx, &p.group, "instance/"+id, func(ctx context.Context) (GetInstanceResponse, error) {
+		generation := p.currentGeneration()
+		resp, err := p.api.GetInstanceById(ctx, id)
+		if err == nil {
+			p.mutex.Lock()
+			if generation == p.generation {
+				p.instances[id] = polled[GetInstanceResponse]{value: resp, at: time.Now()}
+			}
+			p.mutex.Unlock()
+		}
+		return resp, err
+	})
+}
+
+// waitDeletion registers a deletion wait of an instance of the tenant, until the returned function is called
+func (p *instancePoller) waitDeletion(tenantId string) func() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.deletionWaiters[tenantId]++
+	return func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		p.deletionWaiters[tenantId]--
+		if p.deletionWaiters[tenantId] == 0 {
+			delete(p.deletionWaiters, tenantId)
+		}
+	}
+}
+
+// isDeleted reports whether the instance no longer exists, and the tenant of the instance while it exists. With many
+// concurrent deletion waits in the tenant of the instance, a single list call of the tenant answers all of them.
+// The tenant is only known once the instance was fetched, so the first poll of every wait fetches the instance.
+func (p *instancePoller) isDeleted(ctx context.Context, id string, tenantId string) (bool, string, error) {
+	p.mutex.Lock()
+	waiters := p.deletionWaiters[tenantId]
+	cached := p.instanceIds[tenantId]
+	p.mutex.Unlock()
+
+	if tenantId == "" || waiters < listModeThreshold {
+		resp, err := p.api.GetInstanceById(ctx, id)
+		if IsNotFound(err) {
+			return true, tenantId, nil
+		}
+		if err != nil {
+			return false, tenantId, err
+		}
+		return false, resp.Data.TenantId, nil
+	}
+
+	if cached.value != nil && cached.isFresh() {
+		return !cached.value[id], tenantId, nil
+	}
+
+	ids, err := coalesce(ctx, &p.group, instanceIdsKey+tenantId, func(ctx context.Context) (map[string]bool, error) {
+		generation := p.currentGeneration()
+		summaries, err := p.api.ListInstances(ctx, tenantId)
+		if err != nil {
+			return nil, err
+		}
+		ids := make(map[string]bool, len(summaries))
+		for _, summary := range summaries {
+			ids[summary.Id] = true
+		}
+		p.mutex.Lock()
+		if generation == p.generation {
+			p.instanceIds[tenantId] = polled[map[string]bool]{value: ids, at: time.Now()}
+		}
+		p.mutex.Unlock()
+		return ids, nil
+	})
+	if err != nil {
+		return false, tenantId, err
+	}
+	return !ids[id], tenantId, nil
+}
+
+// invalidate discards what was polled for the instance, after a request changed it
+func (p *instancePoller) invalidate(id string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.generation++
+	delete(p.instances, id)
+	for tenantId := range p.instanceIds {
+		p.group.Forget(instanceIdsKey + tenantId)
+	}
+	clear(p.instanceIds)
+	p.group.Forget("instance/" + id)
+}
+
+func (p *instancePoller) currentGeneration() uint64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.generation
+}
+
+// coalesce runs fetch once for all the concurrent callers with the same key. The shared request isn't cancelled
+// with the context of the caller that started it, but every caller stops waiting when its own context is done.
+func coalesce[T any](ctx context.Context, group *singleflight.Group, key string, fetch func(context.Context) (T, error)) (T, error) {
+	results := group.DoChan(key, func() (interface{}, error) {
+		return fetch(context.WithoutCancel(ctx))
+	})
+	select {
+	case result := <-results:
+		value, _ := result.Val.(T)
+		return value, result.Err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
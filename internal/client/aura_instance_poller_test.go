@@ -0,0 +1,112 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/fakeaura"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeAuraApi returns an AuraApi of the fake server
+func newFakeAuraApi(t *testing.T, server *fakeaura.Server) *AuraApi {
+	auraClient, err := NewAuraClient(AuraClientConfig{
+		ClientId:     fakeaura.DefaultClientId,
+		ClientSecret: fakeaura.DefaultClientSecret,
+		BaseUrl:      server.URL(),
+		Version:      "0.0.0-tests",
+	})
+	require.NoError(t, err)
+	return NewAuraApi(auraClient, nil, nil)
+}
+
+func createFakeInstance(t *testing.T, api *AuraApi, name string) string {
+	created, err := api.PostInstance(context.Background(), PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          name,
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      fakeaura.DefaultTenantId,
+	})
+	require.NoError(t, err)
+	return created.Data.Id
+}
+
+func TestConcurrentWaitsAreCoalesced(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newFakeAuraApi(t, server)
+	id := createFakeInstance(t, api, "shared")
+	require.NoError(t, server.ScriptInstanceStatuses(id, domain.InstanceStatusCreating, domain.InstanceStatusRunning))
+
+	const waiters = 10
+	var wg sync.WaitGroup
+	for range waiters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.WaitUntilInstanceHasStatus(context.Background(), id, domain.InstanceStatusRunning)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Less(t, server.Requests("GET /v1/instances/{id}"), waiters)
+}
+
+func TestConcurrentDeletionWaitsListInstances(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer(fakeaura.WithPollsPerStep(3))
+	defer server.Close()
+	api := newFakeAuraApi(t, server)
+
+	ids := make([]string, 6)
+	for i := range ids {
+		ids[i] = createFakeInstance(t, api, fmt.Sprintf("deleted-%d", i))
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		_, err := api.DeleteInstanceById(context.Background(), id)
+		require.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, api.WaitUntilInstanceIsDeleted(context.Background(), id))
+		}()
+	}
+	wg.Wait()
+
+	// The instances are listed in the tenant of the instances only
+	assert.Positive(t, server.Requests("GET /v1/instances"))
+	assert.Equal(t, server.Requests("GET /v1/instances"), server.Requests("GET /v1/instances?tenantId="+fakeaura.DefaultTenantId))
+	for _, id := range ids {
+		_, found := server.Instance(id)
+		assert.False(t, found)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, peak atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", tokenHandler)
+	mux.HandleFunc("GET /v1/tenants", func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	auraClient, err := NewAuraClient(AuraClientConfig{
+		ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL, MaxConcurrentRequests: 2,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := auraClient.Get(context.Background(), "tenants")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", tokenHandler)
+	mux.HandleFunc("GET /v1/tenants", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	auraClient, err := NewAuraClient(AuraClientConfig{
+		ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL, RequestsPerSecond: 20,
+	})
+	require.NoError(t, err)
+
+	// The burst of 20 requests covers the authentication and 19 requests, the 5 others wait for the bucket to refill
+	start := time.Now()
+	for i := 0; i < 24; i++ {
+		_, _, err := auraClient.Get(context.Background(), "tenants")
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestLimiterHonoursContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", tokenHandler)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	auraClient, err := NewAuraClient(AuraClientConfig{
+		ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL, RequestsPerSecond: 0.01,
+	})
+	require.NoError(t, err)
+	_, err = auraClient.auth.GetToken(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err = auraClient.Get(ctx, "tenants")
+	assert.Error(t, err)
+}
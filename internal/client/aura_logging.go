@@ -0,0 +1,259 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	redacted        = "REDACTED"
+	requestIdHeader = "X-Request-Id"
+)
+
+// secretFields are the JSON fields and headers of the Aura API that hold credentials
+var secretFields = []string{"password", "access_token", "client_secret", "authorization"}
+
+// secretPatterns find secrets in JSON documents and in Go structs formatted with %+v
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)"(password|access_token|client_secret)"\s*:\s*"[^"]*"`),
+	regexp.MustCompile(`(?i)\b(password|accesstoken|clientsecret):[^\s}]+`),
+	regexp.MustCompile(`(?i)bearer\s+[\w.~+/=-]+`),
+}
+
+// MaskSecrets configures tflog to mask the Aura credentials and database passwords in the logs of ctx
+func MaskSecrets(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, secretFields...)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, secretPatterns...)
+	return tflog.MaskMessageRegexes(ctx, secretPatterns...)
+}
+
+// loggingTransport logs a structured line for every Aura API request, and records it in the capture file when one
+// is configured
+type loggingTransport struct {
+	next    http.RoundTripper
+	capture *captureFile
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := MaskSecrets(req.Context())
+
+	var requestBody []byte
+	if t.capture != nil && req.Body != nil {
+		var err error
+		if requestBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"duration_ms": duration.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Aura API request failed", fields)
+		if t.capture != nil {
+			t.capture.write(ctx, captureEntry{
+				StartedAt:      start.UTC().Format(time.RFC3339Nano),
+				Method:         req.Method,
+				Url:            req.URL.String(),
+				DurationMs:     duration.Milliseconds(),
+				RequestHeaders: sanitizeHeaders(req.Header),
+				RequestBody:    sanitizeBody(requestBody),
+				Error:          err.Error(),
+			})
+		}
+		return resp, err
+	}
+	fields["status"] = resp.StatusCode
+	if requestId := resp.Header.Get(requestIdHeader); requestId != "" {
+		fields["request_id"] = requestId
+	}
+	tflog.Debug(ctx, "Aura API request", fields)
+
+	if t.capture != nil {
+		responseBody, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+		if readErr != nil {
+			return resp, readErr
+		}
+		t.capture.write(ctx, captureEntry{
+			StartedAt:       start.UTC().Format(time.RFC3339Nano),
+			Method:          req.Method,
+			Url:             req.URL.String(),
+			Status:          resp.StatusCode,
+			DurationMs:      duration.Milliseconds(),
+			RequestId:       resp.Header.Get(requestIdHeader),
+			RequestHeaders:  sanitizeHeaders(req.Header),
+			RequestBody:     sanitizeBody(requestBody),
+			ResponseHeaders: sanitizeHeaders(resp.Header),
+			ResponseBody:    sanitizeBody(responseBody),
+		})
+	}
+	return resp, nil
+}
+
+// captureEntry is a line of the capture file. Requests failing without a response have an error instead of a status.
+type captureEntry struct {
+	StartedAt       string              `json:"started_at"`
+	Method          string              `json:"method"`
+	Url             string              `json:"url"`
+	Status          int                 `json:"status,omitempty"`
+	Error           string              `json:"error,omitempty"`
+	DurationMs      int64               `json:"duration_ms"`
+	RequestId       string              `json:"request_id,omitempty"`
+	RequestHeaders  map[string][]string `json:"request_headers"`
+	RequestBody     json.RawMessage     `json:"request_body,omitempty"`
+	ResponseHeaders map[string][]string `json:"response_headers"`
+	ResponseBody    json.RawMessage     `json:"response_body,omitempty"`
+}
+
+// captureFile appends sanitized requests and responses to a JSON Lines file
+type captureFile struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// captureFiles are the capture files opened by the process, shared by the clients capturing to the same path
+var captureFiles = struct {
+	sync.Mutex
+	byPath map[string]*captureFile
+}{byPath: map[string]*captureFile{}}
+
+func openCaptureFile(path string) (*captureFile, error) {
+	captureFiles.Lock()
+	defer captureFiles.Unlock()
+	if capture, found := captureFiles.byPath[path]; found {
+		return capture, nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	capture := &captureFile{file: file}
+	captureFiles.byPath[path] = capture
+	return capture, nil
+}
+
+// CloseCaptureFiles closes the capture files of the process. It is called when the provider shuts down, the
+// requests sent afterwards are no longer captured.
+func CloseCaptureFiles() error {
+	captureFiles.Lock()
+	defer captureFiles.Unlock()
+	var errs []error
+	for path, capture := range captureFiles.byPath {
+		capture.mutex.Lock()
+		errs = append(errs, capture.file.Close())
+		capture.mutex.Unlock()
+		delete(captureFiles.byPath, path)
+	}
+	return errors.Join(errs...)
+}
+
+func (c *captureFile) write(ctx context.Context, entry captureEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		tflog.Warn(ctx, "Cannot encode the captured request", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		tflog.Warn(ctx, "Cannot write the captured request", map[string]interface{}{"error": err.Error()})
+	}
+}
+
+func sanitizeHeaders(headers http.Header) map[string][]string {
+	sanitized := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		if isSecretField(name) {
+			sanitized[name] = []string{redacted}
+		} else {
+			sanitized[name] = values
+		}
+	}
+	return sanitized
+}
+
+// sanitizeBody redacts the secret fields of a JSON body. Other bodies are captured as a JSON string.
+func sanitizeBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var document any
+	if err := json.Unmarshal(body, &document); err == nil {
+		if sanitized, err := json.Marshal(redactSecretFields(document)); err == nil {
+			return sanitized
+		}
+	}
+	text := string(body)
+	for _, pattern := range secretPatterns {
+		text = pattern.ReplaceAllString(text, redacted)
+	}
+	sanitized, _ := json.Marshal(text)
+	return sanitized
+}
+
+func redactSecretFields(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isSecretField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactSecretFields(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactSecretFields(item)
+		}
+	}
+	return value
+}
+
+func isSecretField(name string) bool {
+	for _, field := range secretFields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
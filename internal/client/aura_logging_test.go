@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPassword    = "db-password-123"
+	testAccessToken = "secret-access-token"
+)
+
+func newCapturedServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"access_token":%q,"expires_in":3600,"token_type":"bearer"}`, testAccessToken)
+	})
+	mux.HandleFunc("POST /v1/instances", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(requestIdHeader, "request-1")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, `{"data":{"id":"instance-1","username":"neo4j","password":%q}}`, testPassword)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLoggingTransportMasksSecrets(t *testing.T) {
+	t.Parallel()
+
+	server := newCapturedServer(t)
+	auraClient, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "client-secret", BaseUrl: server.URL})
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	_, status, err := auraClient.Post(ctx, "instances", []byte(`{"name":"test"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	require.NoError(t, err)
+	var requestLogs []map[string]interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "Aura API request" {
+			requestLogs = append(requestLogs, entry)
+		}
+	}
+	require.Len(t, requestLogs, 2)
+	assert.Equal(t, "POST", requestLogs[1]["method"])
+	assert.Equal(t, "/v1/instances", requestLogs[1]["path"])
+	assert.Equal(t, float64(http.StatusAccepted), requestLogs[1]["status"])
+	assert.Equal(t, "request-1", requestLogs[1]["request_id"])
+	assert.Contains(t, requestLogs[1], "duration_ms")
+
+	masked := MaskSecrets(ctx)
+	tflog.Debug(masked, fmt.Sprintf("Created %+v", PostInstanceData{Id: "instance-1", Password: testPassword}),
+		map[string]interface{}{"password": testPassword})
+	assert.NotContains(t, logs.String(), testPassword)
+}
+
+func TestCaptureFileIsSanitized(t *testing.T) {
+	t.Parallel()
+
+	server := newCapturedServer(t)
+	captureFile := filepath.Join(t.TempDir(), "capture.jsonl")
+	auraClient, err := NewAuraClient(AuraClientConfig{
+		ClientId: "id", ClientSecret: "client-secret", BaseUrl: server.URL, CaptureFile: captureFile,
+	})
+	require.NoError(t, err)
+
+	_, _, err = auraClient.Post(context.Background(), "instances", []byte(`{"name":"test"}`))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(captureFile)
+	require.NoError(t, err)
+	for _, secret := range []string{testPassword, testAccessToken, "client-secret"} {
+		assert.NotContains(t, string(content), secret)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+	var entry captureEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "POST", entry.Method)
+	assert.Equal(t, "request-1", entry.RequestId)
+	assert.Equal(t, []string{redacted}, entry.RequestHeaders["Authorization"])
+	assert.JSONEq(t, `{"name":"test"}`, string(entry.RequestBody))
+	assert.JSONEq(t, `{"data":{"id":"instance-1","username":"neo4j","password":"REDACTED"}}`, string(entry.ResponseBody))
+}
+
+// TestCaptureFileRecordsFailedRequests isn't parallel, as it closes the capture files of the other tests
+func TestCaptureFileRecordsFailedRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", tokenHandler)
+	mux.HandleFunc("GET /v1/instances", func(w http.ResponseWriter, r *http.Request) {
+		// Drop the connection without a response
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	captureFile := filepath.Join(t.TempDir(), "capture.jsonl")
+	maxRetries := 0
+	auraClient, err := NewAuraClient(AuraClientConfig{
+		ClientId: "id", ClientSecret: "client-secret", BaseUrl: server.URL, CaptureFile: captureFile, MaxRetries: &maxRetries,
+	})
+	require.NoError(t, err)
+
+	_, _, err = auraClient.Get(context.Background(), "instances")
+	require.Error(t, err)
+	require.NoError(t, CloseCaptureFiles())
+
+	content, err := os.ReadFile(captureFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+	var entry captureEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "GET", entry.Method)
+	assert.Zero(t, entry.Status)
+	assert.NotEmpty(t, entry.Error)
+}
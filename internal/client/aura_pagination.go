@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxPages guards against a list endpoint that keeps returning a next page
+const maxPages = 1000
+
+// listPage is the envelope of the Aura list endpoints
+type listPage[T any] struct {
+	Data []T `json:"data"`
+	// Next is the path of the next page, when the list is paginated
+	Next string `json:"next,omitempty"`
+}
+
+// listAll returns the items of every page of a list endpoint, starting from path
+func listAll[T any](ctx context.Context, auraClient *AuraClient, path string) ([]T, error) {
+	items := []T{}
+	visited := map[string]bool{}
+	for page := 0; path != ""; page++ {
+		if page == maxPages || visited[path] {
+			return nil, fmt.Errorf("pagination of %s doesn't end", path)
+		}
+		visited[path] = true
+
+		current, err := call[noBody, listPage[T]](ctx, auraClient, http.MethodGet, path, nil, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, current.Data...)
+		path = relativePath(current.Next)
+	}
+	return items, nil
+}
+
+// relativePath strips the api version from a next page path, as AuraClient adds it
+func relativePath(next string) string {
+	if i := strings.Index(next, "/"+auraV1Path+"/"); i >= 0 {
+		return next[i+len(auraV1Path)+2:]
+	}
+	return strings.TrimPrefix(next, "/")
+}
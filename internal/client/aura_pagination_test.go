@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/fakeaura"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAllFollowsNextPages(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", tokenHandler)
+	mux.HandleFunc("GET /v1/instances", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tenant-1", r.URL.Query().Get("tenantId"))
+		switch r.URL.Query().Get("page") {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"a","name":"first"}],"next":"/v1/instances?tenantId=tenant-1&page=2"}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"b","name":"second"}]}`))
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	auraClient, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL})
+	require.NoError(t, err)
+	instances, err := NewAuraApi(auraClient, nil, nil).ListInstances(context.Background(), "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, []InstanceSummary{{Id: "a", Name: "first"}, {Id: "b", Name: "second"}}, instances)
+}
+
+func TestListAllStopsOnPaginationLoop(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", tokenHandler)
+	mux.HandleFunc("GET /v1/instances", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[],"next":"/v1/instances"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	auraClient, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL})
+	require.NoError(t, err)
+	_, err = listAll[InstanceSummary](context.Background(), auraClient, "instances")
+	assert.ErrorContains(t, err, "pagination of instances doesn't end")
+}
+
+func TestListInstances(t *testing.T) {
+	t.Parallel()
+
+	const otherTenantId = "0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b"
+	server := fakeaura.NewServer(fakeaura.WithTenants(
+		fakeaura.Tenant{Id: fakeaura.DefaultTenantId, Name: fakeaura.DefaultTenantName},
+		fakeaura.Tenant{Id: otherTenantId, Name: "Other"},
+	))
+	defer server.Close()
+	api := newFakeAuraApi(t, server)
+	ctx := context.Background()
+
+	id := createFakeInstance(t, api, "listed")
+	_, err := api.PostInstance(ctx, PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          "other",
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      otherTenantId,
+	})
+	require.NoError(t, err)
+
+	instances, err := api.ListInstances(ctx, fakeaura.DefaultTenantId)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, id, instances[0].Id)
+	assert.Equal(t, "listed", instances[0].Name)
+	assert.Equal(t, fakeaura.DefaultTenantId, instances[0].TenantId)
+	assert.Equal(t, domain.CloudProviderGcp, instances[0].CloudProvider)
+	createdAt, err := instances[0].CreatedAtAsTime()
+	require.NoError(t, err)
+	assert.False(t, createdAt.IsZero())
+
+	all, err := api.ListInstances(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
@@ -0,0 +1,129 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type contextKey string
+
+const nonIdempotentRequestKey contextKey = "nonIdempotentRequest"
+
+var rateLimitHeaders = []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"}
+
+// withNonIdempotentRequest marks requests that create resources in Aura. These are only retried
+// when Aura provably did not process them, so a retry never creates a duplicate instance or snapshot.
+func withNonIdempotentRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonIdempotentRequestKey, true)
+}
+
+func isNonIdempotentRequest(ctx context.Context) bool {
+	nonIdempotent, _ := ctx.Value(nonIdempotentRequestKey).(bool)
+	return nonIdempotent
+}
+
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if resp != nil {
+		logRateLimitHeaders(ctx, resp)
+		// Rate limited requests are rejected before being processed, so they are always safe to retry
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return true, nil
+		}
+	}
+
+	if isNonIdempotentRequest(ctx) {
+		// A request that never reached Aura is safe to retry
+		var opErr *net.OpError
+		if err != nil && errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true, nil
+		}
+		if err != nil || (resp != nil && resp.StatusCode >= 500) {
+			tflog.Warn(ctx, "Not retrying a request that creates resources, as Aura may have processed it")
+		}
+		return false, nil
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// backoff waits for the duration requested by Aura's Retry-After header when present,
+// otherwise it uses an exponential backoff with jitter, bounded by min and max.
+func backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := float64(min) * math.Pow(2, float64(attemptNum))
+	if wait > float64(max) || math.IsInf(wait, 0) {
+		wait = float64(max)
+	}
+	// Jitter between half and the full wait, so parallel resources don't retry in lockstep
+	jittered := time.Duration(wait/2 + rand.Float64()*wait/2)
+	if jittered < min {
+		return min
+	}
+	return jittered
+}
+
+// parseRetryAfter supports both formats of the Retry-After header: delay in seconds and HTTP date
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+func logRateLimitHeaders(ctx context.Context, resp *http.Response) {
+	fields := map[string]interface{}{}
+	for _, header := range rateLimitHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			fields[header] = value
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		tflog.Warn(ctx, "Aura API rate limit reached", fields)
+	} else if len(fields) > 0 {
+		tflog.Debug(ctx, "Aura API rate limit status", fields)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRetry(t *testing.T) {
+	t.Parallel()
+
+	idempotent := context.Background()
+	nonIdempotent := withNonIdempotentRequest(context.Background())
+	dialError := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readError := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	cases := map[string]struct {
+		ctx           context.Context
+		status        int
+		err           error
+		expectedRetry bool
+	}{
+		"rate limited get":              {ctx: idempotent, status: 429, expectedRetry: true},
+		"rate limited post":             {ctx: nonIdempotent, status: 429, expectedRetry: true},
+		"server error get":              {ctx: idempotent, status: 503, expectedRetry: true},
+		"server error post":             {ctx: nonIdempotent, status: 503, expectedRetry: false},
+		"connection reset post":         {ctx: nonIdempotent, err: readError, expectedRetry: false},
+		"connection refused post":       {ctx: nonIdempotent, err: dialError, expectedRetry: true},
+		"successful post":               {ctx: nonIdempotent, status: 202, expectedRetry: false},
+		"client error get":              {ctx: idempotent, status: 400, expectedRetry: false},
+		"connection reset get":          {ctx: idempotent, err: readError, expectedRetry: true},
+		"successful get with rate info": {ctx: idempotent, status: 200, expectedRetry: false},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp *http.Response
+			if tc.err == nil {
+				resp = &http.Response{StatusCode: tc.status, Header: http.Header{"X-Ratelimit-Remaining": []string{"10"}}}
+			}
+			retry, err := checkRetry(tc.ctx, resp, tc.err)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRetry, retry)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	rateLimited := &http.Response{StatusCode: 429, Header: http.Header{"Retry-After": []string{"42"}}}
+	assert.Equal(t, 42*time.Second, backoff(time.Second, 30*time.Second, 1, rateLimited))
+
+	httpDate := &http.Response{StatusCode: 503, Header: http.Header{"Retry-After": []string{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}}}
+	assert.Equal(t, time.Duration(0), backoff(time.Second, 30*time.Second, 1, httpDate))
+
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := backoff(time.Second, 30*time.Second, attempt, nil)
+		assert.GreaterOrEqual(t, wait, time.Second)
+		assert.LessOrEqual(t, wait, 30*time.Second)
+	}
+}
@@ -0,0 +1,274 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	operationKey contextKey = "operation"
+	retriesKey   contextKey = "retries"
+
+	tracerName  = "github.com/neo4j-labs/terraform-provider-neo4jaura"
+	serviceName = "terraform-provider-neo4jaura"
+)
+
+// otlpEndpointVariables enable the export of spans when one of them is set. The exporter reads its other
+// OTEL_EXPORTER_OTLP_* settings, e.g. headers or timeout, from the environment too.
+var otlpEndpointVariables = []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"}
+
+// Telemetry records the count, latency and retries of every Aura API operation and the duration of the waits for
+// instances and snapshots. It's shared by the clients of the provider process, and exports spans to an
+// OpenTelemetry collector when one is configured.
+type Telemetry struct {
+	mutex      sync.Mutex
+	operations map[string]*stats
+	waits      map[string]*stats
+
+	startOnce sync.Once
+	// logCtx carries the logger of the first Configure request, it is nil until then
+	logCtx         context.Context
+	tracer         trace.Tracer
+	tracerProvider *sdktrace.TracerProvider
+}
+
+type stats struct {
+	count   int
+	errors  int
+	retries int64
+	total   time.Duration
+	max     time.Duration
+}
+
+func (s *stats) record(duration time.Duration, retries int64, err error) {
+	s.count++
+	s.retries += retries
+	s.total += duration
+	s.max = max(s.max, duration)
+	if err != nil {
+		s.errors++
+	}
+}
+
+func NewTelemetry() *Telemetry {
+	return &Telemetry{
+		operations: map[string]*stats{},
+		waits:      map[string]*stats{},
+		tracer:     noop.NewTracerProvider().Tracer(tracerName),
+	}
+}
+
+// Start enables the export of spans when an OTLP endpoint is configured, the first time it's called. The summary
+// is logged on Shutdown with the logger of ctx, as the contexts of the plugin server are gone by then. When Start was
+// never called, e.g. the provider wasn't configured for terraform validate, the summary is logged with the standard
+// logger, which Terraform also collects from the provider process.
+func (t *Telemetry) Start(ctx context.Context, version string) error {
+	var err error
+	t.startOnce.Do(func() {
+		t.logCtx = ctx
+		if !otlpEndpointConfigured() {
+			return
+		}
+		exporter, exporterErr := otlptracehttp.New(ctx)
+		if exporterErr != nil {
+			err = exporterErr
+			return
+		}
+		t.tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(sdkresource.NewSchemaless(
+				attribute.String("service.name", serviceName),
+				attribute.String("service.version", version),
+			)),
+		)
+		t.tracer = t.tracerProvider.Tracer(tracerName)
+		tflog.Info(ctx, "Exporting Aura API telemetry to OpenTelemetry")
+	})
+	return err
+}
+
+func otlpEndpointConfigured() bool {
+	for _, variable := range otlpEndpointVariables {
+		if os.Getenv(variable) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Shutdown logs the summary of the recorded telemetry, and flushes the spans not exported yet
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	t.logSummary()
+	if t.tracerProvider == nil {
+		return nil
+	}
+	return t.tracerProvider.Shutdown(ctx)
+}
+
+func (t *Telemetry) logSummary() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	// Synchronizes with Start setting logCtx, and stops a later Start from setting it
+	t.startOnce.Do(func() {})
+
+	var apiTime, waitTime time.Duration
+	for _, name := range sortedNames(t.operations) {
+		s := t.operations[name]
+		apiTime += s.total
+		t.logInfo("Aura API operation summary", summaryFields(name, s, map[string]interface{}{
+			"errors":  s.errors,
+			"retries": s.retries,
+		}))
+	}
+	for _, name := range sortedNames(t.waits) {
+		s := t.waits[name]
+		waitTime += s.total
+		t.logInfo("Aura wait summary", summaryFields(name, s, map[string]interface{}{"failures": s.errors}))
+	}
+	t.logInfo(fmt.Sprintf("Spent %s in Aura API operations and %s waiting for Aura",
+		apiTime.Round(time.Millisecond), waitTime.Round(time.Millisecond)), map[string]interface{}{
+		"api_ms":  apiTime.Milliseconds(),
+		"wait_ms": waitTime.Milliseconds(),
+	})
+}
+
+// logInfo logs with the logger of the Configure request, or with the standard logger when there was none
+func (t *Telemetry) logInfo(message string, fields map[string]interface{}) {
+	if t.logCtx != nil {
+		tflog.Info(t.logCtx, message, fields)
+		return
+	}
+	log.Printf("[INFO] %s: %v", message, fields)
+}
+
+func summaryFields(name string, s *stats, fields map[string]interface{}) map[string]interface{} {
+	fields["name"] = name
+	fields["count"] = s.count
+	fields["total_ms"] = s.total.Milliseconds()
+	fields["mean_ms"] = (s.total / time.Duration(s.count)).Milliseconds()
+	fields["max_ms"] = s.max.Milliseconds()
+	return fields
+}
+
+// startOperation records an Aura API operation, named after the generated endpoint stub when ctx carries one.
+// The returned function ends it with the status of the response.
+func (t *Telemetry) startOperation(ctx context.Context, method string, path string) (context.Context, func(auraResponse, error)) {
+	name, ok := ctx.Value(operationKey).(string)
+	if !ok {
+		name = method + " " + path
+	}
+	retryCount := &atomic.Int64{}
+	ctx = context.WithValue(ctx, retriesKey, retryCount)
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.request.method", method),
+		attribute.String("url.path", path),
+	))
+	start := time.Now()
+
+	return ctx, func(resp auraResponse, err error) {
+		duration := time.Since(start)
+		retries := retryCount.Load()
+		t.mutex.Lock()
+		s, found := t.operations[name]
+		if !found {
+			s = &stats{}
+			t.operations[name] = s
+		}
+		failed := err
+		if failed == nil && resp.status >= http.StatusBadRequest {
+			failed = resp.error()
+		}
+		s.record(duration, retries, failed)
+		t.mutex.Unlock()
+
+		span.SetAttributes(attribute.Int64("aura.retries", retries))
+		if resp.status != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.status))
+		}
+		if resp.requestId != "" {
+			span.SetAttributes(attribute.String("aura.request_id", resp.requestId))
+		}
+		if failed != nil {
+			span.SetStatus(codes.Error, failed.Error())
+		}
+		span.End()
+	}
+}
+
+// startWait records a wait for an instance or a snapshot. The returned function ends it with its outcome.
+func (t *Telemetry) startWait(ctx context.Context, name string, id string) (context.Context, func(error)) {
+	ctx, span := t.tracer.Start(ctx, "wait "+name, trace.WithAttributes(attribute.String("aura.id", id)))
+	start := time.Now()
+
+	return ctx, func(err error) {
+		duration := time.Since(start)
+		t.mutex.Lock()
+		s, found := t.waits[name]
+		if !found {
+			s = &stats{}
+			t.waits[name] = s
+		}
+		s.record(duration, 0, err)
+		t.mutex.Unlock()
+
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// countRetry is the request hook of the retryable client, counting the retries of the operation of the request
+func countRetry(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if retryCount, ok := req.Context().Value(retriesKey).(*atomic.Int64); ok && attempt > 0 {
+		retryCount.Add(1)
+	}
+}
+
+// withOperation names the Aura API operation sent with ctx
+func withOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey, name)
+}
+
+func sortedNames(m map[string]*stats) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,156 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTelemetryServer(t *testing.T) *httptest.Server {
+	var tenantRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", tokenHandler)
+	mux.HandleFunc("GET /v1/tenants", func(w http.ResponseWriter, r *http.Request) {
+		if tenantRequests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	mux.HandleFunc("GET /v1/instances/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(requestIdHeader, "request-1")
+		_, _ = w.Write([]byte(`{"data":{"id":"instance-1","status":"running"}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTelemetryApi(t *testing.T, server *httptest.Server, telemetry *Telemetry) *AuraApi {
+	retryWait := time.Millisecond
+	auraClient, err := NewAuraClient(AuraClientConfig{
+		ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL,
+		RetryWaitMin: &retryWait, RetryWaitMax: &retryWait, Telemetry: telemetry,
+	})
+	require.NoError(t, err)
+	return NewAuraApi(auraClient, nil, nil)
+}
+
+func TestTelemetrySummarizesOperationsAndWaits(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	telemetry := NewTelemetry()
+	require.NoError(t, telemetry.Start(ctx, "test"))
+	api := newTelemetryApi(t, newTelemetryServer(t), telemetry)
+
+	_, err := api.GetTenants(ctx)
+	require.NoError(t, err)
+	_, err = api.WaitUntilInstanceHasStatus(ctx, "instance-1", "running")
+	require.NoError(t, err)
+	require.NoError(t, telemetry.Shutdown(ctx))
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	require.NoError(t, err)
+	summaries := map[string]map[string]interface{}{}
+	var total map[string]interface{}
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "Aura API operation summary", "Aura wait summary":
+			summaries[entry["name"].(string)] = entry
+		default:
+			if _, ok := entry["wait_ms"]; ok {
+				total = entry
+			}
+		}
+	}
+	require.Contains(t, summaries, "listTenants")
+	assert.Equal(t, float64(1), summaries["listTenants"]["count"])
+	assert.Equal(t, float64(1), summaries["listTenants"]["retries"])
+	assert.Equal(t, float64(0), summaries["listTenants"]["errors"])
+	require.Contains(t, summaries, "getInstance")
+	assert.Equal(t, float64(1), summaries["getInstance"]["count"])
+	require.Contains(t, summaries, "instance")
+	assert.Equal(t, float64(0), summaries["instance"]["failures"])
+	require.NotNil(t, total)
+	assert.Contains(t, total["@message"], "waiting for Aura")
+}
+
+// TestTelemetryLogsSummaryWithoutConfigure isn't parallel, as it redirects the standard logger
+func TestTelemetryLogsSummaryWithoutConfigure(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	require.NoError(t, NewTelemetry().Shutdown(context.Background()))
+	assert.Contains(t, logs.String(), "[INFO] Spent 0s in Aura API operations and 0s waiting for Aura")
+}
+
+func TestTelemetryExportsSpansToCollector(t *testing.T) {
+	var mutex sync.Mutex
+	var spanNames []string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request coltracepb.ExportTraceServiceRequest
+		require.NoError(t, proto.Unmarshal(body, &request))
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, resourceSpans := range request.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				for _, span := range scopeSpans.Spans {
+					spanNames = append(spanNames, span.Name)
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+
+	ctx := context.Background()
+	telemetry := NewTelemetry()
+	require.NoError(t, telemetry.Start(ctx, "test"))
+	api := newTelemetryApi(t, newTelemetryServer(t), telemetry)
+
+	_, err := api.WaitUntilInstanceHasStatus(ctx, "instance-1", "running")
+	require.NoError(t, err)
+	require.NoError(t, telemetry.Shutdown(ctx))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.ElementsMatch(t, []string{"getInstance", "wait instance"}, spanNames)
+}
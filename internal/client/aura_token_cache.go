@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const tokenCacheDirName = "terraform-provider-neo4jaura"
+
+// tokenCache persists OAuth tokens on disk, so that the provider processes Terraform starts
+// for validate, plan and apply can share a single token
+type tokenCache struct {
+	dir string
+}
+
+type cachedToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiringAt  int64  `json:"expiring_at"`
+}
+
+// DefaultTokenCacheDir returns the directory of the token cache in the user cache directory
+func DefaultTokenCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, tokenCacheDirName, "tokens"), nil
+}
+
+// path returns the cache file of the given credentials. Only a hash of the client id and secret is used in the file
+// name, so that a wrong or rotated secret doesn't reuse the token of the previous one.
+func (c *tokenCache) path(baseUrl, clientId, clientSecret string) string {
+	hash := sha256.Sum256([]byte(baseUrl + "\n" + clientId + "\n" + clientSecret))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+// lock takes an exclusive lock on the cache file, waiting for other provider processes to release it
+func (c *tokenCache) lock(path string) (func(), error) {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return nil, err
+	}
+	lockFile, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFileExclusive(lockFile); err != nil {
+		lockFile.Close()
+		return nil, err
+	}
+	return func() {
+		_ = unlockFile(lockFile)
+		_ = lockFile.Close()
+	}, nil
+}
+
+func (c *tokenCache) load(path string) (*AuraAuthToken, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cached cachedToken
+	if err := json.Unmarshal(content, &cached); err != nil {
+		return nil, err
+	}
+	return &AuraAuthToken{token: cached.AccessToken, expiringAt: cached.ExpiringAt}, nil
+}
+
+func (c *tokenCache) store(path string, token *AuraAuthToken) error {
+	content, err := json.Marshal(cachedToken{AccessToken: token.token, ExpiringAt: token.expiringAt})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
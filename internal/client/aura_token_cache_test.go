@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenCacheIsSharedBetweenClients(t *testing.T) {
+	t.Parallel()
+
+	var authentications atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authentications.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"cached-token","expires_in":3600,"token_type":"bearer"}`))
+	}))
+	defer server.Close()
+
+	config := AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL, TokenCacheDir: t.TempDir()}
+	for i := 0; i < 3; i++ {
+		auraClient, err := NewAuraClient(config)
+		require.NoError(t, err)
+		token, err := auraClient.auth.GetToken(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "cached-token", token)
+	}
+	assert.Equal(t, int32(1), authentications.Load())
+
+	config.ClientId = "other-id"
+	auraClient, err := NewAuraClient(config)
+	require.NoError(t, err)
+	_, err = auraClient.auth.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), authentications.Load())
+
+	// A rotated secret doesn't reuse the token of the previous one
+	config.ClientSecret = "rotated-secret"
+	auraClient, err = NewAuraClient(config)
+	require.NoError(t, err)
+	_, err = auraClient.auth.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), authentications.Load())
+}
+
+func TestTokenCacheIgnoresExpiringTokens(t *testing.T) {
+	t.Parallel()
+
+	cache := &tokenCache{dir: t.TempDir()}
+	path := cache.path("https://api.neo4j.io", "id", "secret")
+	assert.NotContains(t, path, "id")
+	assert.NotContains(t, path, "secret")
+
+	unlock, err := cache.lock(path)
+	require.NoError(t, err)
+	defer unlock()
+
+	expiring := &AuraAuthToken{token: "token", expiringAt: time.Now().Unix() + expirationBuffer/2}
+	require.NoError(t, cache.store(path, expiring))
+
+	loaded, err := cache.load(path)
+	require.NoError(t, err)
+	assert.Equal(t, expiring, loaded)
+	assert.False(t, loaded.isValid())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tokenHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600,"token_type":"bearer"}`))
+}
+
+func TestCaCertFileIsTrusted(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(tokenHandler))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertFile, certificate, 0o600))
+
+	noRetries := 0
+	untrusting, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL, MaxRetries: &noRetries})
+	require.NoError(t, err)
+	_, err = untrusting.auth.GetToken(context.Background())
+	assert.ErrorContains(t, err, "certificate")
+
+	trusting, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL, CaCertFile: caCertFile})
+	require.NoError(t, err)
+	_, err = trusting.auth.GetToken(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestInvalidTransportConfiguration(t *testing.T) {
+	t.Parallel()
+
+	notPem := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPem, []byte("not a certificate"), 0o600))
+
+	_, err := NewAuraClient(AuraClientConfig{CaCertFile: notPem})
+	assert.ErrorContains(t, err, "no PEM certificate found")
+
+	_, err = NewAuraClient(AuraClientConfig{ClientCertFile: notPem, ClientKeyFile: notPem})
+	assert.ErrorContains(t, err, "cannot load client certificate")
+
+	_, err = NewAuraClient(AuraClientConfig{ProxyUrl: "://proxy"})
+	assert.ErrorContains(t, err, "invalid proxy url")
+}
+
+func TestProxyUrlIsUsed(t *testing.T) {
+	t.Parallel()
+
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Add(1)
+		assert.Equal(t, "http://aura.invalid/oauth/token", r.URL.String())
+		tokenHandler(w, r)
+	}))
+	defer proxy.Close()
+
+	auraClient, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: "http://aura.invalid", ProxyUrl: proxy.URL})
+	require.NoError(t, err)
+	_, err = auraClient.auth.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), proxied.Load())
+}
+
+func TestTotalRequestTimeoutIncludesRetries(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	timeout := 200 * time.Millisecond
+	auraClient, err := NewAuraClient(AuraClientConfig{ClientId: "id", ClientSecret: "secret", BaseUrl: server.URL, Timeout: &timeout})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = auraClient.auth.GetToken(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/util"
 )
 
 var (
@@ -108,7 +109,7 @@ func (ds *ProjectsDataSource) Read(ctx context.Context, request datasource.ReadR
 
 	tenantsResponse, err := ds.auraApi.GetTenants(ctx)
 	if err != nil {
-		response.Diagnostics.AddError("Error while reading projects", err.Error())
+		util.AddError(&response.Diagnostics, "Error while reading projects", err)
 		return
 	}
 
@@ -30,6 +30,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/util"
 )
 
 var (
@@ -158,13 +159,13 @@ func (ds *SnapshotDataSource) Configure(ctx context.Context, request datasource.
 func (ds *SnapshotDataSource) readMostRecentSnapshot(ctx context.Context, instanceId string, response *datasource.ReadResponse) *client.GetSnapshotData {
 	snapshots, err := ds.auraApi.GetSnapshotsByInstanceId(ctx, instanceId)
 	if err != nil {
-		response.Diagnostics.AddError("Error while reading instance snapshots", err.Error())
+		util.AddError(&response.Diagnostics, "Error while reading instance snapshots", err)
 		return nil
 	}
 	if len(snapshots.Data) == 0 {
 		isRecentlyCreated, err := ds.isInstanceRecentlyCreated(ctx, instanceId)
 		if err != nil {
-			response.Diagnostics.AddError("Cannot read instance "+instanceId, err.Error())
+			util.AddError(&response.Diagnostics, "Cannot read instance "+instanceId, err)
 			return nil
 		}
 		if isRecentlyCreated {
@@ -172,7 +173,7 @@ func (ds *SnapshotDataSource) readMostRecentSnapshot(ctx context.Context, instan
 				return len(data.Data) > 0
 			})
 			if err != nil {
-				response.Diagnostics.AddError("Cannot find snapshot for instance "+instanceId, err.Error())
+				util.AddError(&response.Diagnostics, "Cannot find snapshot for instance "+instanceId, err)
 				return nil
 			}
 		} else {
@@ -212,7 +213,7 @@ func (ds *SnapshotDataSource) isInstanceRecentlyCreated(ctx context.Context, ins
 func (ds *SnapshotDataSource) readSnapshotById(ctx context.Context, instanceId, snapshotId string, response *datasource.ReadResponse) *client.GetSnapshotData {
 	snapshots, err := ds.auraApi.GetSnapshotsByInstanceId(ctx, instanceId)
 	if err != nil {
-		response.Diagnostics.AddError("Error while reading instance snapshots", err.Error())
+		util.AddError(&response.Diagnostics, "Error while reading instance snapshots", err)
 		return nil
 	}
 	if len(snapshots.Data) == 0 {
@@ -72,6 +72,12 @@ const (
 	InstanceStorage2048GB string = "2048GB"
 )
 
+const (
+	InstanceOnDestroyDelete             string = "delete"
+	InstanceOnDestroyPause              string = "pause"
+	InstanceOnDestroySnapshotThenDelete string = "snapshot_then_delete"
+)
+
 const (
 	CdcEnrichmentModeOff  string = "OFF"
 	CdcEnrichmentModeDiff string = "DIFF"
@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package domain
+
+import "strings"
+
+// instanceStatusTransitions lists the statuses an instance can move to from a given status
+var instanceStatusTransitions = map[string][]string{
+	InstanceStatusCreating:      {InstanceStatusRunning, InstanceStatusLoading, InstanceStatusLoadingFailed, InstanceStatusDestroying},
+	InstanceStatusLoading:       {InstanceStatusRunning, InstanceStatusLoadingFailed, InstanceStatusDestroying},
+	InstanceStatusLoadingFailed: {InstanceStatusDestroying},
+	InstanceStatusRunning: {
+		InstanceStatusPausing, InstanceStatusUpdating, InstanceStatusRestoring, InstanceStatusOverwriting,
+		InstanceStatusSuspending, InstanceStatusDestroying,
+	},
+	InstanceStatusPausing:     {InstanceStatusPaused},
+	InstanceStatusPaused:      {InstanceStatusResuming, InstanceStatusDestroying},
+	InstanceStatusResuming:    {InstanceStatusRunning},
+	InstanceStatusSuspending:  {InstanceStatusSuspended},
+	InstanceStatusSuspended:   {InstanceStatusResuming, InstanceStatusDestroying},
+	InstanceStatusRestoring:   {InstanceStatusRunning, InstanceStatusLoadingFailed},
+	InstanceStatusUpdating:    {InstanceStatusRunning},
+	InstanceStatusOverwriting: {InstanceStatusRunning, InstanceStatusLoadingFailed},
+	InstanceStatusDestroying:  {},
+}
+
+var instanceFailureStatuses = []string{InstanceStatusLoadingFailed}
+
+// snapshotStatusTransitions lists the statuses a snapshot can move to from a given status
+var snapshotStatusTransitions = map[string][]string{
+	SnapshotStatusPending:    {SnapshotStatusInProgress, SnapshotStatusCompleted, SnapshotStatusFailed},
+	SnapshotStatusInProgress: {SnapshotStatusCompleted, SnapshotStatusFailed},
+	SnapshotStatusCompleted:  {},
+	SnapshotStatusFailed:     {},
+}
+
+// IsInstanceStatusFailure reports whether the instance status is a terminal failure
+func IsInstanceStatusFailure(status string) bool {
+	return containsFold(instanceFailureStatuses, status)
+}
+
+// InstanceStatusCanReach reports whether an instance in status from can eventually be in status to.
+// Statuses unknown to the provider are assumed to be able to reach any status.
+func InstanceStatusCanReach(from, to string) bool {
+	return canReach(instanceStatusTransitions, strings.ToLower(from), strings.ToLower(to))
+}
+
+// SnapshotStatusCanReach reports whether a snapshot in status from can eventually be in status to.
+// Statuses unknown to the provider are assumed to be able to reach any status.
+func SnapshotStatusCanReach(from, to string) bool {
+	return canReach(snapshotStatusTransitions, canonicalSnapshotStatus(from), canonicalSnapshotStatus(to))
+}
+
+func canReach(transitions map[string][]string, from, to string) bool {
+	if from == to {
+		return true
+	}
+	if _, known := transitions[from]; !known {
+		return true
+	}
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range transitions[current] {
+			if next == to {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+func canonicalSnapshotStatus(status string) string {
+	for known := range snapshotStatusTransitions {
+		if strings.EqualFold(known, status) {
+			return known
+		}
+	}
+	return status
+}
+
+func containsFold(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceStatusCanReach(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{from: InstanceStatusCreating, to: InstanceStatusRunning, expected: true},
+		{from: InstanceStatusCreating, to: InstanceStatusPaused, expected: true},
+		{from: InstanceStatusPaused, to: InstanceStatusRunning, expected: true},
+		{from: InstanceStatusPausing, to: InstanceStatusRunning, expected: true},
+		{from: "Running", to: InstanceStatusPaused, expected: true},
+		{from: InstanceStatusLoadingFailed, to: InstanceStatusRunning, expected: false},
+		{from: InstanceStatusDestroying, to: InstanceStatusRunning, expected: false},
+		{from: InstanceStatusRunning, to: InstanceStatusCreating, expected: false},
+		{from: "some future status", to: InstanceStatusRunning, expected: true},
+	}
+
+	for _, tc := range cases {
+		assert.Equalf(t, tc.expected, InstanceStatusCanReach(tc.from, tc.to), "%s -> %s", tc.from, tc.to)
+	}
+}
+
+func TestSnapshotStatusCanReach(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, SnapshotStatusCanReach(SnapshotStatusPending, SnapshotStatusCompleted))
+	assert.True(t, SnapshotStatusCanReach("inprogress", SnapshotStatusCompleted))
+	assert.False(t, SnapshotStatusCanReach(SnapshotStatusFailed, SnapshotStatusCompleted))
+	assert.False(t, SnapshotStatusCanReach(SnapshotStatusCompleted, SnapshotStatusInProgress))
+}
+
+func TestFailureStatuses(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsInstanceStatusFailure("Loading Failed"))
+	assert.False(t, IsInstanceStatusFailure(InstanceStatusRunning))
+}
@@ -0,0 +1,812 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package fakeaura provides an in-memory stand-in for the Aura API, so the provider can be
+// exercised end to end without network access or Aura credentials.
+package fakeaura
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+)
+
+const (
+	DefaultClientId     = "fake-client-id"
+	DefaultClientSecret = "fake-client-secret"
+	DefaultTenantId     = "6e7c5d7e-1f2a-4b3c-8d4e-5f6a7b8c9d0e"
+	DefaultTenantName   = "Fake Project"
+
+	tokenExpiresIn = 3600
+)
+
+type Server struct {
+	server *httptest.Server
+
+	mutex        sync.Mutex
+	clientId     string
+	clientSecret string
+	tokens       map[string]bool
+	tenants      []Tenant
+	instances    map[string]*Instance
+	instanceIds  []string
+	snapshots    map[string][]*Snapshot
+	pollsPerStep int
+	requests     map[string]int
+
+	droppedCreations  int
+	rejectedCreations []int
+	creationStatuses  map[string][]string
+}
+
+type Tenant struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Instance is the server side representation of an Aura instance. Status transitions queued in
+// Pending are applied one at a time, every pollsPerStep reads of the instance.
+type Instance struct {
+	Id                   string  `json:"id"`
+	Name                 string  `json:"name"`
+	Status               string  `json:"status"`
+	TenantId             string  `json:"tenant_id"`
+	CloudProvider        string  `json:"cloud_provider"`
+	ConnectionUrl        string  `json:"connection_url"`
+	Region               string  `json:"region"`
+	Type                 string  `json:"type"`
+	Memory               string  `json:"memory"`
+	Storage              *string `json:"storage"`
+	CreatedAt            string  `json:"created_at"`
+	GraphNodes           *int64  `json:"graph_nodes"`
+	GraphRelationships   *int64  `json:"graph_relationships"`
+	SecondariesCount     *int    `json:"secondaries_count"`
+	CdcEnrichmentMode    *string `json:"cdc_enrichment_mode"`
+	VectorOptimized      *bool   `json:"vector_optimized"`
+	GraphAnalyticsPlugin *bool   `json:"graph_analytics_plugin"`
+
+	Username string   `json:"-"`
+	Password string   `json:"-"`
+	Pending  []string `json:"-"`
+	polls    int
+}
+
+// Snapshot is the server side representation of an instance snapshot. Status transitions
+// behave like the ones of Instance.
+type Snapshot struct {
+	InstanceId string `json:"instance_id"`
+	SnapshotId string `json:"snapshot_id"`
+	Profile    string `json:"profile"`
+	Status     string `json:"status"`
+	Timestamp  string `json:"timestamp"`
+	Exportable bool   `json:"exportable"`
+
+	Pending []string `json:"-"`
+	polls   int
+}
+
+type Option func(*Server)
+
+// WithCredentials sets the client id and secret accepted by the token endpoint.
+func WithCredentials(clientId, clientSecret string) Option {
+	return func(s *Server) {
+		s.clientId = clientId
+		s.clientSecret = clientSecret
+	}
+}
+
+// WithTenants replaces the default tenant returned by the tenants endpoint.
+func WithTenants(tenants ...Tenant) Option {
+	return func(s *Server) {
+		s.tenants = tenants
+	}
+}
+
+// WithPollsPerStep sets how many reads of an instance or snapshot are needed before
+// the next queued status transition is applied. Defaults to 1.
+func WithPollsPerStep(polls int) Option {
+	return func(s *Server) {
+		s.pollsPerStep = max(polls, 1)
+	}
+}
+
+// NewServer starts a fake Aura API. Close must be called once the server is no longer needed.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		clientId:     DefaultClientId,
+		clientSecret: DefaultClientSecret,
+		tokens:       map[string]bool{},
+		tenants:      []Tenant{{Id: DefaultTenantId, Name: DefaultTenantName}},
+		instances:    map[string]*Instance{},
+		snapshots:    map[string][]*Snapshot{},
+		pollsPerStep: 1,
+		requests:     map[string]int{},
+
+		creationStatuses: map[string][]string{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", s.handleToken)
+	mux.HandleFunc("GET /v1/tenants", s.authenticated(s.handleGetTenants))
+	mux.HandleFunc("GET /v1/tenants/{id}", s.authenticated(s.handleGetTenant))
+	mux.HandleFunc("GET /v1/instances", s.authenticated(s.handleListInstances))
+	mux.HandleFunc("POST /v1/instances", s.authenticated(s.handlePostInstance))
+	mux.HandleFunc("GET /v1/instances/{id}", s.authenticated(s.handleGetInstance))
+	mux.HandleFunc("PATCH /v1/instances/{id}", s.authenticated(s.handlePatchInstance))
+	mux.HandleFunc("DELETE /v1/instances/{id}", s.authenticated(s.handleDeleteInstance))
+	mux.HandleFunc("POST /v1/instances/{id}/pause", s.authenticated(s.handlePauseInstance))
+	mux.HandleFunc("POST /v1/instances/{id}/resume", s.authenticated(s.handleResumeInstance))
+	mux.HandleFunc("POST /v1/instances/{id}/overwrite", s.authenticated(s.handleOverwriteInstance))
+	mux.HandleFunc("GET /v1/instances/{id}/snapshots", s.authenticated(s.handleGetSnapshots))
+	mux.HandleFunc("POST /v1/instances/{id}/snapshots", s.authenticated(s.handlePostSnapshot))
+	mux.HandleFunc("GET /v1/instances/{id}/snapshots/{snapshotId}", s.authenticated(s.handleGetSnapshot))
+	mux.HandleFunc("POST /v1/instances/{id}/snapshots/{snapshotId}/restore", s.authenticated(s.handleRestoreSnapshot))
+
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+// URL returns the base url of the server, suitable for the provider base_url attribute.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Requests returns the number of authenticated requests received for the route pattern, e.g. "GET /v1/instances/{id}",
+// for the route pattern and query, e.g. "GET /v1/instances?tenantId=...", or for the path, e.g.
+// "POST /v1/instances/1234abcd/snapshots"
+func (s *Server) Requests(pattern string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests[pattern]
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Instance returns a copy of the instance with the given id.
+func (s *Server) Instance(id string) (Instance, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instance, ok := s.instances[id]
+	if !ok {
+		return Instance{}, false
+	}
+	return *instance, true
+}
+
+// ScriptInstanceStatuses replaces the status transitions that the instance goes through on the following reads.
+func (s *Server) ScriptInstanceStatuses(id string, statuses ...string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instance, ok := s.instances[id]
+	if !ok {
+		return fmt.Errorf("instance %s not found", id)
+	}
+	instance.Pending = statuses
+	instance.polls = 0
+	return nil
+}
+
+// ScriptSnapshotStatuses replaces the status transitions that the snapshot goes through on the following reads.
+func (s *Server) ScriptSnapshotStatuses(instanceId, snapshotId string, statuses ...string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	snapshot := s.findSnapshot(instanceId, snapshotId)
+	if snapshot == nil {
+		return fmt.Errorf("snapshot %s of instance %s not found", snapshotId, instanceId)
+	}
+	snapshot.Pending = statuses
+	snapshot.polls = 0
+	return nil
+}
+
+// DropNextCreationResponse makes the next instance creation succeed, but closes the connection instead of
+// responding, as if the response was lost.
+func (s *Server) DropNextCreationResponse() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.droppedCreations++
+}
+
+// RejectNextCreation makes the next instance creation fail with the given status, without creating the instance.
+func (s *Server) RejectNextCreation(status int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rejectedCreations = append(s.rejectedCreations, status)
+}
+
+// ScriptCreationStatuses replaces the status transitions that the instances created with the given name go through.
+func (s *Server) ScriptCreationStatuses(name string, statuses ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.creationStatuses[name] = statuses
+}
+
+// DeleteInstance removes the instance immediately, as if it was deleted outside of Terraform.
+func (s *Server) DeleteInstance(id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeInstance(id)
+}
+
+// DeleteSnapshot removes the snapshot immediately, as if it expired.
+func (s *Server) DeleteSnapshot(instanceId, snapshotId string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	snapshots := s.snapshots[instanceId]
+	for i, snapshot := range snapshots {
+		if snapshot.SnapshotId == snapshotId {
+			s.snapshots[instanceId] = append(snapshots[:i], snapshots[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Server) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mutex.Lock()
+		valid := found && s.tokens[token]
+		s.requests[r.Pattern]++
+		if path := r.Method + " " + r.URL.Path; path != r.Pattern {
+			s.requests[path]++
+		}
+		if r.URL.RawQuery != "" {
+			s.requests[r.Pattern+"?"+r.URL.RawQuery]++
+		}
+		s.mutex.Unlock()
+		if !valid {
+			writeError(w, http.StatusUnauthorized, "Unauthorized", "")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	clientId, clientSecret, ok := r.BasicAuth()
+	if !ok || clientId != s.clientId || clientSecret != s.clientSecret {
+		writeError(w, http.StatusUnauthorized, "Invalid client credentials", "")
+		return
+	}
+	token := newId()
+	s.mutex.Lock()
+	s.tokens[token] = true
+	s.mutex.Unlock()
+	writeJson(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"expires_in":   tokenExpiresIn,
+		"token_type":   "bearer",
+	})
+}
+
+func (s *Server) handleGetTenants(w http.ResponseWriter, _ *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	writeData(w, http.StatusOK, s.tenants)
+}
+
+func (s *Server) handleGetTenant(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, tenant := range s.tenants {
+		if tenant.Id == r.PathValue("id") {
+			writeData(w, http.StatusOK, map[string]any{
+				"id":                      tenant.Id,
+				"name":                    tenant.Name,
+				"instance_configurations": []any{},
+			})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("Tenant %s not found", r.PathValue("id")), "")
+}
+
+func (s *Server) handleListInstances(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tenantId := r.URL.Query().Get("tenantId")
+	summaries := []map[string]any{}
+	for _, id := range slices.Clone(s.instanceIds) {
+		instance, ok := s.readInstance(id)
+		if !ok || tenantId != "" && instance.TenantId != tenantId {
+			continue
+		}
+		summaries = append(summaries, map[string]any{
+			"id":             instance.Id,
+			"name":           instance.Name,
+			"created_at":     instance.CreatedAt,
+			"tenant_id":      instance.TenantId,
+			"cloud_provider": instance.CloudProvider,
+		})
+	}
+	writeData(w, http.StatusOK, summaries)
+}
+
+type postInstanceRequest struct {
+	Version              string  `json:"version"`
+	Region               string  `json:"region"`
+	Memory               string  `json:"memory"`
+	Name                 string  `json:"name"`
+	Type                 string  `json:"type"`
+	TenantId             string  `json:"tenant_id"`
+	CloudProvider        string  `json:"cloud_provider"`
+	Storage              *string `json:"storage"`
+	VectorOptimized      *bool   `json:"vector_optimized"`
+	GraphAnalyticsPlugin *bool   `json:"graph_analytics_plugin"`
+	SourceInstanceId     *string `json:"source_instance_id"`
+	SourceSnapshotId     *string `json:"source_snapshot_id"`
+}
+
+func (s *Server) handlePostInstance(w http.ResponseWriter, r *http.Request) {
+	var request postInstanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error(), "")
+		return
+	}
+	for field, value := range map[string]string{
+		"name": request.Name, "region": request.Region, "memory": request.Memory, "type": request.Type,
+		"tenant_id": request.TenantId, "cloud_provider": request.CloudProvider, "version": request.Version,
+	} {
+		if value == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Missing required field %s", field), field)
+			return
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.rejectedCreations) > 0 {
+		status := s.rejectedCreations[0]
+		s.rejectedCreations = s.rejectedCreations[1:]
+		writeError(w, status, http.StatusText(status), "")
+		return
+	}
+	if !s.hasTenant(request.TenantId) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Tenant %s not found", request.TenantId), "tenant_id")
+		return
+	}
+	pending := []string{domain.InstanceStatusRunning}
+	if request.SourceInstanceId != nil {
+		source, ok := s.instances[*request.SourceInstanceId]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Instance %s not found", *request.SourceInstanceId), "source_instance_id")
+			return
+		}
+		if request.SourceSnapshotId != nil && s.findSnapshot(source.Id, *request.SourceSnapshotId) == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Snapshot %s not found", *request.SourceSnapshotId), "source_snapshot_id")
+			return
+		}
+		pending = []string{domain.InstanceStatusLoading, domain.InstanceStatusRunning}
+	}
+	if statuses, ok := s.creationStatuses[request.Name]; ok {
+		pending = slices.Clone(statuses)
+	}
+
+	id := newId()[:8]
+	instance := &Instance{
+		Id:                   id,
+		Name:                 request.Name,
+		Status:               domain.InstanceStatusCreating,
+		TenantId:             request.TenantId,
+		CloudProvider:        request.CloudProvider,
+		ConnectionUrl:        fmt.Sprintf("neo4j+s://%s.databases.neo4j.io", id),
+		Region:               request.Region,
+		Type:                 request.Type,
+		Memory:               request.Memory,
+		Storage:              request.Storage,
+		CreatedAt:            time.Now().UTC().Format(time.RFC3339),
+		VectorOptimized:      request.VectorOptimized,
+		GraphAnalyticsPlugin: request.GraphAnalyticsPlugin,
+		Username:             "neo4j",
+		Password:             newId(),
+		Pending:              pending,
+	}
+	if instance.Storage == nil {
+		storage := doubled(request.Memory)
+		instance.Storage = &storage
+	}
+	if instance.VectorOptimized == nil {
+		instance.VectorOptimized = new(bool)
+	}
+	if instance.GraphAnalyticsPlugin == nil {
+		instance.GraphAnalyticsPlugin = new(bool)
+	}
+	if request.Type == domain.InstanceTypeFreeDb {
+		instance.GraphNodes = new(int64)
+		instance.GraphRelationships = new(int64)
+	}
+	s.instances[id] = instance
+	s.instanceIds = append(s.instanceIds, id)
+
+	if s.droppedCreations > 0 {
+		s.droppedCreations--
+		dropConnection(w)
+		return
+	}
+	writeData(w, http.StatusAccepted, map[string]any{
+		"id":             instance.Id,
+		"name":           instance.Name,
+		"tenant_id":      instance.TenantId,
+		"cloud_provider": instance.CloudProvider,
+		"connection_url": instance.ConnectionUrl,
+		"region":         instance.Region,
+		"type":           instance.Type,
+		"username":       instance.Username,
+		"password":       instance.Password,
+	})
+}
+
+func (s *Server) handleGetInstance(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instance, ok := s.readInstance(r.PathValue("id"))
+	if !ok {
+		writeInstanceNotFound(w, r.PathValue("id"))
+		return
+	}
+	writeData(w, http.StatusOK, instance)
+}
+
+type patchInstanceRequest struct {
+	Name                 *string `json:"name"`
+	Memory               *string `json:"memory"`
+	Storage              *string `json:"storage"`
+	CdcEnrichmentMode    *string `json:"cdc_enrichment_mode"`
+	SecondariesCount     *int    `json:"secondaries_count"`
+	VectorOptimized      *bool   `json:"vector_optimized"`
+	GraphAnalyticsPlugin *bool   `json:"graph_analytics_plugin"`
+}
+
+func (s *Server) handlePatchInstance(w http.ResponseWriter, r *http.Request) {
+	var request patchInstanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error(), "")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instance, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeInstanceNotFound(w, r.PathValue("id"))
+		return
+	}
+	if request.Name != nil {
+		instance.Name = *request.Name
+	}
+	if request.CdcEnrichmentMode != nil {
+		instance.CdcEnrichmentMode = request.CdcEnrichmentMode
+	}
+	if request.SecondariesCount != nil {
+		instance.SecondariesCount = request.SecondariesCount
+	}
+	if request.VectorOptimized != nil {
+		instance.VectorOptimized = request.VectorOptimized
+	}
+	if request.GraphAnalyticsPlugin != nil {
+		instance.GraphAnalyticsPlugin = request.GraphAnalyticsPlugin
+	}
+	resized := false
+	if request.Memory != nil && *request.Memory != instance.Memory {
+		instance.Memory = *request.Memory
+		resized = true
+	}
+	if request.Storage != nil && (instance.Storage == nil || *request.Storage != *instance.Storage) {
+		instance.Storage = request.Storage
+		resized = true
+	}
+	if resized && instance.Status == domain.InstanceStatusRunning {
+		instance.Status = domain.InstanceStatusUpdating
+		instance.Pending = []string{domain.InstanceStatusRunning}
+		instance.polls = 0
+	}
+	writeData(w, http.StatusAccepted, instance)
+}
+
+func (s *Server) handleDeleteInstance(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instance, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeInstanceNotFound(w, r.PathValue("id"))
+		return
+	}
+	instance.Status = domain.InstanceStatusDestroying
+	instance.Pending = []string{""}
+	// Aura deletes the snapshots of an instance with it
+	delete(s.snapshots, instance.Id)
+	instance.polls = 0
+	writeData(w, http.StatusAccepted, instance)
+}
+
+func (s *Server) handlePauseInstance(w http.ResponseWriter, r *http.Request) {
+	s.transitionInstance(w, r, domain.InstanceStatusRunning, domain.InstanceStatusPausing, domain.InstanceStatusPaused)
+}
+
+func (s *Server) handleResumeInstance(w http.ResponseWriter, r *http.Request) {
+	s.transitionInstance(w, r, domain.InstanceStatusPaused, domain.InstanceStatusResuming, domain.InstanceStatusRunning)
+}
+
+func (s *Server) transitionInstance(w http.ResponseWriter, r *http.Request, from, via, to string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instance, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeInstanceNotFound(w, r.PathValue("id"))
+		return
+	}
+	if instance.Status != from {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Instance %s is %s, expected %s", instance.Id, instance.Status, from), "")
+		return
+	}
+	instance.Status = via
+	instance.Pending = []string{to}
+	instance.polls = 0
+	writeData(w, http.StatusAccepted, instance)
+}
+
+type overwriteInstanceRequest struct {
+	SourceInstanceId *string `json:"source_instance_id"`
+	SourceSnapshotId *string `json:"source_snapshot_id"`
+}
+
+func (s *Server) handleOverwriteInstance(w http.ResponseWriter, r *http.Request) {
+	var request overwriteInstanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error(), "")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instance, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		writeInstanceNotFound(w, r.PathValue("id"))
+		return
+	}
+	sourceInstanceId := instance.Id
+	if request.SourceInstanceId != nil {
+		if _, ok := s.instances[*request.SourceInstanceId]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Instance %s not found", *request.SourceInstanceId), "source_instance_id")
+			return
+		}
+		sourceInstanceId = *request.SourceInstanceId
+	}
+	if request.SourceSnapshotId != nil && s.findSnapshot(sourceInstanceId, *request.SourceSnapshotId) == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Snapshot %s not found", *request.SourceSnapshotId), "source_snapshot_id")
+		return
+	}
+	s.loadInstance(w, instance, domain.InstanceStatusOverwriting)
+}
+
+func (s *Server) handleGetSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instanceId := r.PathValue("id")
+	if _, ok := s.instances[instanceId]; !ok {
+		writeInstanceNotFound(w, instanceId)
+		return
+	}
+	snapshots := []Snapshot{}
+	for _, snapshot := range s.snapshots[instanceId] {
+		snapshots = append(snapshots, *s.advanceSnapshot(snapshot))
+	}
+	writeData(w, http.StatusOK, snapshots)
+}
+
+func (s *Server) handlePostSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instanceId := r.PathValue("id")
+	if _, ok := s.instances[instanceId]; !ok {
+		writeInstanceNotFound(w, instanceId)
+		return
+	}
+	snapshot := s.addSnapshot(instanceId, domain.SnapshotProfileAdHoc)
+	writeData(w, http.StatusAccepted, map[string]any{"snapshot_id": snapshot.SnapshotId})
+}
+
+func (s *Server) handleGetSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instanceId, snapshotId := r.PathValue("id"), r.PathValue("snapshotId")
+	snapshot := s.findSnapshot(instanceId, snapshotId)
+	if snapshot == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Snapshot %s not found", snapshotId), "")
+		return
+	}
+	writeData(w, http.StatusOK, s.advanceSnapshot(snapshot))
+}
+
+func (s *Server) handleRestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	instanceId, snapshotId := r.PathValue("id"), r.PathValue("snapshotId")
+	instance, ok := s.instances[instanceId]
+	if !ok {
+		writeInstanceNotFound(w, instanceId)
+		return
+	}
+	if s.findSnapshot(instanceId, snapshotId) == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Snapshot %s not found", snapshotId), "")
+		return
+	}
+	s.loadInstance(w, instance, domain.InstanceStatusRestoring)
+}
+
+// loadInstance replaces the data of a running instance, going through the given status.
+// It must be called with the mutex held.
+func (s *Server) loadInstance(w http.ResponseWriter, instance *Instance, via string) {
+	if instance.Status != domain.InstanceStatusRunning {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Instance %s is %s, expected %s", instance.Id, instance.Status, domain.InstanceStatusRunning), "")
+		return
+	}
+	instance.Status = via
+	instance.Pending = []string{domain.InstanceStatusRunning}
+	instance.polls = 0
+	writeData(w, http.StatusAccepted, instance)
+}
+
+// readInstance applies the next pending transition when due and returns the instance.
+// It must be called with the mutex held.
+func (s *Server) readInstance(id string) (*Instance, bool) {
+	instance, ok := s.instances[id]
+	if !ok {
+		return nil, false
+	}
+	if len(instance.Pending) == 0 {
+		return instance, true
+	}
+	instance.polls++
+	if instance.polls < s.pollsPerStep {
+		return instance, true
+	}
+	instance.polls = 0
+	next := instance.Pending[0]
+	instance.Pending = instance.Pending[1:]
+	if next == "" {
+		s.removeInstance(id)
+		return nil, false
+	}
+	previous := instance.Status
+	instance.Status = next
+	if previous == domain.InstanceStatusCreating || previous == domain.InstanceStatusLoading {
+		if next == domain.InstanceStatusRunning && len(s.snapshots[id]) == 0 {
+			s.addSnapshot(id, domain.SnapshotProfileScheduled)
+		}
+	}
+	return instance, true
+}
+
+// advanceSnapshot applies the next pending transition when due. It must be called with the mutex held.
+func (s *Server) advanceSnapshot(snapshot *Snapshot) *Snapshot {
+	if len(snapshot.Pending) == 0 {
+		return snapshot
+	}
+	snapshot.polls++
+	if snapshot.polls < s.pollsPerStep {
+		return snapshot
+	}
+	snapshot.polls = 0
+	snapshot.Status = snapshot.Pending[0]
+	snapshot.Pending = snapshot.Pending[1:]
+	return snapshot
+}
+
+func (s *Server) addSnapshot(instanceId, profile string) *Snapshot {
+	snapshot := &Snapshot{
+		InstanceId: instanceId,
+		SnapshotId: newId(),
+		Profile:    profile,
+		Status:     domain.SnapshotStatusInProgress,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Exportable: true,
+		Pending:    []string{domain.SnapshotStatusCompleted},
+	}
+	s.snapshots[instanceId] = append(s.snapshots[instanceId], snapshot)
+	return snapshot
+}
+
+func (s *Server) findSnapshot(instanceId, snapshotId string) *Snapshot {
+	for _, snapshot := range s.snapshots[instanceId] {
+		if snapshot.SnapshotId == snapshotId {
+			return snapshot
+		}
+	}
+	return nil
+}
+
+func (s *Server) removeInstance(id string) {
+	delete(s.instances, id)
+	delete(s.snapshots, id)
+	for i, instanceId := range s.instanceIds {
+		if instanceId == id {
+			s.instanceIds = append(s.instanceIds[:i], s.instanceIds[i+1:]...)
+			break
+		}
+	}
+}
+
+func (s *Server) hasTenant(id string) bool {
+	for _, tenant := range s.tenants {
+		if tenant.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func dropConnection(w http.ResponseWriter) {
+	connection, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error(), "")
+		return
+	}
+	_ = connection.Close()
+}
+
+func writeInstanceNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("Instance %s not found", id), "")
+}
+
+func writeError(w http.ResponseWriter, status int, message string, field string) {
+	apiError := map[string]string{"message": message, "reason": strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "-")}
+	if field != "" {
+		apiError["field"] = field
+	}
+	writeJson(w, status, map[string]any{"errors": []map[string]string{apiError}})
+}
+
+func writeData(w http.ResponseWriter, status int, data any) {
+	writeJson(w, status, map[string]any{"data": data})
+}
+
+func writeJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newId() string {
+	bytes := make([]byte, 16)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
+
+// doubled returns the default storage size for a given memory size, as Aura allocates twice the memory.
+func doubled(memory string) string {
+	var size int
+	if _, err := fmt.Sscanf(memory, "%dGB", &size); err != nil {
+		return memory
+	}
+	return fmt.Sprintf("%dGB", size*2)
+}
@@ -0,0 +1,241 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package fakeaura_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/fakeaura"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newApi(t *testing.T, server *fakeaura.Server) *client.AuraApi {
+	auraClient, err := client.NewAuraClient(client.AuraClientConfig{
+		ClientId:     fakeaura.DefaultClientId,
+		ClientSecret: fakeaura.DefaultClientSecret,
+		BaseUrl:      server.URL(),
+		Version:      "0.0.0-tests",
+	})
+	require.NoError(t, err)
+	return client.NewAuraApi(auraClient, nil, nil)
+}
+
+func TestInstanceStatusTransitions(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer(fakeaura.WithPollsPerStep(2))
+	defer server.Close()
+	api := newApi(t, server)
+	ctx := context.Background()
+
+	created, err := api.PostInstance(ctx, client.PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          "fake",
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      fakeaura.DefaultTenantId,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Data.Password)
+
+	statuses := make([]string, 0, 3)
+	for range 3 {
+		instance, err := api.GetInstanceById(ctx, created.Data.Id)
+		require.NoError(t, err)
+		statuses = append(statuses, instance.Data.Status)
+	}
+	assert.Equal(t, []string{domain.InstanceStatusCreating, domain.InstanceStatusRunning, domain.InstanceStatusRunning}, statuses)
+
+	_, err = api.PauseInstanceById(ctx, created.Data.Id)
+	require.NoError(t, err)
+	paused, err := api.WaitUntilInstanceIsInState(ctx, created.Data.Id, func(r client.GetInstanceResponse) bool {
+		return r.Data.Status == domain.InstanceStatusPaused
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.InstanceStatusPaused, paused.Data.Status)
+
+	_, err = api.DeleteInstanceById(ctx, created.Data.Id)
+	require.NoError(t, err)
+	require.NoError(t, api.WaitUntilInstanceIsDeleted(ctx, created.Data.Id))
+}
+
+func TestScriptedSnapshotStatuses(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newApi(t, server)
+	ctx := context.Background()
+
+	created, err := api.PostInstance(ctx, client.PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          "fake",
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      fakeaura.DefaultTenantId,
+	})
+	require.NoError(t, err)
+
+	snapshot, err := api.PostSnapshot(ctx, created.Data.Id)
+	require.NoError(t, err)
+	require.NoError(t, server.ScriptSnapshotStatuses(created.Data.Id, snapshot.Data.SnapshotId,
+		domain.SnapshotStatusPending, domain.SnapshotStatusInProgress, domain.SnapshotStatusFailed))
+
+	statuses := make([]string, 0, 3)
+	for range 3 {
+		s, err := api.GetSnapshotById(ctx, created.Data.Id, snapshot.Data.SnapshotId)
+		require.NoError(t, err)
+		statuses = append(statuses, s.Data.Status)
+	}
+	assert.Equal(t, []string{domain.SnapshotStatusPending, domain.SnapshotStatusInProgress, domain.SnapshotStatusFailed}, statuses)
+}
+
+func TestSnapshotsAreDeletedWithTheirInstance(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newApi(t, server)
+	ctx := context.Background()
+
+	created, err := api.PostInstance(ctx, client.PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          "fake",
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      fakeaura.DefaultTenantId,
+	})
+	require.NoError(t, err)
+	snapshot, err := api.PostSnapshot(ctx, created.Data.Id)
+	require.NoError(t, err)
+
+	_, err = api.DeleteInstanceById(ctx, created.Data.Id)
+	require.NoError(t, err)
+	_, err = api.GetSnapshotById(ctx, created.Data.Id, snapshot.Data.SnapshotId)
+	assert.True(t, client.IsNotFound(err), "expected snapshot %s to be deleted, got %v", snapshot.Data.SnapshotId, err)
+}
+
+func TestWaitingStopsOnFailureStatus(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newApi(t, server)
+	ctx := context.Background()
+
+	created, err := api.PostInstance(ctx, client.PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          "clone",
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      fakeaura.DefaultTenantId,
+	})
+	require.NoError(t, err)
+	require.NoError(t, server.ScriptInstanceStatuses(created.Data.Id, domain.InstanceStatusLoading, domain.InstanceStatusLoadingFailed))
+
+	start := time.Now()
+	_, err = api.WaitUntilInstanceHasStatus(ctx, created.Data.Id, domain.InstanceStatusRunning)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), domain.InstanceStatusLoadingFailed)
+	assert.Less(t, time.Since(start), 30*time.Second)
+
+	snapshot, err := api.PostSnapshot(ctx, created.Data.Id)
+	require.NoError(t, err)
+	require.NoError(t, server.ScriptSnapshotStatuses(created.Data.Id, snapshot.Data.SnapshotId, domain.SnapshotStatusFailed))
+
+	_, err = api.WaitUntilSnapshotHasStatus(ctx, created.Data.Id, snapshot.Data.SnapshotId, domain.SnapshotStatusCompleted)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), domain.SnapshotStatusFailed)
+}
+
+func createInstance(t *testing.T, api *client.AuraApi, name string) string {
+	created, err := api.PostInstance(context.Background(), client.PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          name,
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      fakeaura.DefaultTenantId,
+	})
+	require.NoError(t, err)
+	return created.Data.Id
+}
+
+func TestGetTenantById(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newApi(t, server)
+
+	tenant, err := api.GetTenantById(context.Background(), fakeaura.DefaultTenantId)
+	require.NoError(t, err)
+	assert.Equal(t, fakeaura.DefaultTenantName, tenant.Data.Name)
+
+	_, err = api.GetTenantById(context.Background(), "missing")
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestOverwriteAndRestoreInstance(t *testing.T) {
+	t.Parallel()
+
+	server := fakeaura.NewServer()
+	defer server.Close()
+	api := newApi(t, server)
+	ctx := context.Background()
+
+	source := createInstance(t, api, "source")
+	target := createInstance(t, api, "target")
+	_, err := api.WaitUntilInstanceHasStatus(ctx, source, domain.InstanceStatusRunning)
+	require.NoError(t, err)
+	_, err = api.WaitUntilInstanceHasStatus(ctx, target, domain.InstanceStatusRunning)
+	require.NoError(t, err)
+
+	overwritten, err := api.OverwriteInstance(ctx, target, client.OverwriteInstanceRequest{SourceInstanceId: &source})
+	require.NoError(t, err)
+	assert.Equal(t, domain.InstanceStatusOverwriting, overwritten.Data.Status)
+	_, err = api.WaitUntilInstanceHasStatus(ctx, target, domain.InstanceStatusRunning)
+	require.NoError(t, err)
+
+	snapshots, err := api.WaitUntilSnapshotsMatchCondition(ctx, target, func(r client.GetSnapshotsResponse) bool {
+		return len(r.Data) > 0
+	})
+	require.NoError(t, err)
+	restored, err := api.RestoreSnapshot(ctx, target, snapshots.Data[0].SnapshotId)
+	require.NoError(t, err)
+	assert.Equal(t, domain.InstanceStatusRestoring, restored.Data.Status)
+	_, err = api.WaitUntilInstanceHasStatus(ctx, target, domain.InstanceStatusRunning)
+	require.NoError(t, err)
+
+	_, err = api.RestoreSnapshot(ctx, target, "missing")
+	assert.True(t, client.IsNotFound(err))
+}
@@ -0,0 +1,481 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Command openapigen generates the request and response types, the enum values and the endpoint stubs of the
+// Aura client from the OpenAPI specification of the Aura API.
+//
+// The schemas keep their name in the specification, unless the -names file maps it to another Go type name, so that a
+// published specification can be checked in unchanged.
+//
+// Only the schemas used by the request bodies and successful responses of the operations are generated, the error
+// responses are decoded by newAuraError. Operations whose response has a next property are paginated: their types
+// are generated, but not their stubs, as listAll fetches them.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path of the OpenAPI specification")
+	outPath := flag.String("out", "", "path of the generated Go file")
+	namesPath := flag.String("names", "", "path of an optional JSON object mapping schema names to Go type names")
+	pkg := flag.String("package", "client", "package of the generated Go file")
+	flag.Parse()
+
+	if err := run(*specPath, *namesPath, *outPath, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "openapigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, namesPath, outPath, pkg string) error {
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+	names, err := readNames(namesPath)
+	if err != nil {
+		return err
+	}
+	generated, err := generate(content, path.Base(specPath), pkg, names)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, generated, 0o644)
+}
+
+// readNames reads the mapping of the schema names to Go type names, which is empty without a names file
+func readNames(namesPath string) (map[string]string, error) {
+	names := map[string]string{}
+	if namesPath == "" {
+		return names, nil
+	}
+	content, err := os.ReadFile(namesPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &names); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", path.Base(namesPath), err)
+	}
+	return names, nil
+}
+
+type spec struct {
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Parameters map[string]*parameter `json:"parameters"`
+		Schemas    map[string]*schema    `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationId string       `json:"operationId"`
+	Summary     string       `json:"summary"`
+	Parameters  []*parameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]mediaType `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]mediaType `json:"content"`
+	} `json:"responses"`
+}
+
+type parameter struct {
+	Ref  string  `json:"$ref"`
+	Name string  `json:"name"`
+	In   string  `json:"in"`
+	Type *schema `json:"schema"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref         string             `json:"$ref"`
+	Type        string             `json:"type"`
+	Format      string             `json:"format"`
+	Description string             `json:"description"`
+	Enum        []string           `json:"enum"`
+	Items       *schema            `json:"items"`
+	Properties  map[string]*schema `json:"properties"`
+	Required    []string           `json:"required"`
+}
+
+// endpoint is an operation of the specification, resolved for code generation
+type endpoint struct {
+	name         string
+	summary      string
+	method       string
+	path         string
+	pathParams   []string
+	queryParams  []string
+	request      string
+	emptyRequest bool
+	response     string
+	statuses     []string
+}
+
+type generator struct {
+	spec *spec
+	used map[string]bool
+	// names maps schema names to Go type names
+	names map[string]string
+}
+
+func generate(content []byte, source string, pkg string, names map[string]string) ([]byte, error) {
+	var s spec
+	if err := json.Unmarshal(content, &s); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", source, err)
+	}
+	for name := range names {
+		if _, ok := s.Components.Schemas[name]; !ok {
+			return nil, fmt.Errorf("%s: the names file maps the unknown schema %s", source, name)
+		}
+	}
+	g := &generator{spec: &s, used: map[string]bool{}, names: names}
+
+	endpoints, err := g.endpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by openapigen from %s. DO NOT EDIT.\n\npackage %s\n\n", source, pkg)
+	out.WriteString("import (\n\"context\"\n\"net/http\"\n")
+	if slices.ContainsFunc(endpoints, endpoint.usesUrl) {
+		out.WriteString("\"net/url\"\n")
+	}
+	out.WriteString(")\n\n")
+	g.writeEnums(&out)
+	if err := g.writeTypes(&out); err != nil {
+		return nil, err
+	}
+	for _, e := range endpoints {
+		e.write(&out)
+	}
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format the generated code: %w\n%s", err, out.String())
+	}
+	return formatted, nil
+}
+
+func (g *generator) endpoints() ([]endpoint, error) {
+	var endpoints []endpoint
+	for _, p := range sortedKeys(g.spec.Paths) {
+		for _, method := range sortedKeys(g.spec.Paths[p]) {
+			op := g.spec.Paths[p][method]
+			e := endpoint{
+				name:    op.OperationId,
+				summary: op.Summary,
+				method:  "http.Method" + exported(strings.ToLower(method)),
+				path:    strings.TrimPrefix(p, "/"),
+			}
+			for _, param := range op.Parameters {
+				param, err := g.resolveParameter(param)
+				if err != nil {
+					return nil, err
+				}
+				switch param.In {
+				case "path":
+					e.pathParams = append(e.pathParams, param.Name)
+				case "query":
+					e.queryParams = append(e.queryParams, param.Name)
+				default:
+					return nil, fmt.Errorf("%s: unsupported parameter location %q", op.OperationId, param.In)
+				}
+			}
+			if op.RequestBody != nil {
+				name := schemaName(op.RequestBody.Content["application/json"].Schema)
+				if name == "" {
+					return nil, fmt.Errorf("%s: the request body must reference a schema", op.OperationId)
+				}
+				g.use(name)
+				e.request = g.goName(name)
+				e.emptyRequest = len(g.spec.Components.Schemas[name].Properties) == 0
+			}
+			paginated := false
+			for _, status := range sortedKeys(op.Responses) {
+				if !strings.HasPrefix(status, "2") {
+					continue
+				}
+				e.statuses = append(e.statuses, status)
+				name := schemaName(op.Responses[status].Content["application/json"].Schema)
+				if name == "" {
+					return nil, fmt.Errorf("%s: the %s response must reference a schema", op.OperationId, status)
+				}
+				g.use(name)
+				e.response = g.goName(name)
+				_, paginated = g.spec.Components.Schemas[name].Properties["next"]
+			}
+			if e.response == "" {
+				return nil, fmt.Errorf("%s: no successful response", op.OperationId)
+			}
+			if !paginated {
+				endpoints = append(endpoints, e)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+func (g *generator) resolveParameter(param *parameter) (*parameter, error) {
+	if param.Ref == "" {
+		return param, nil
+	}
+	resolved, ok := g.spec.Components.Parameters[path.Base(param.Ref)]
+	if !ok {
+		return nil, fmt.Errorf("unknown parameter %s", param.Ref)
+	}
+	return resolved, nil
+}
+
+// use marks the schema, and the schemas it references, as generated
+func (g *generator) use(name string) {
+	if g.used[name] {
+		return
+	}
+	g.used[name] = true
+	s := g.spec.Components.Schemas[name]
+	if s == nil {
+		return
+	}
+	for _, property := range s.Properties {
+		for property != nil {
+			if ref := schemaName(property); ref != "" {
+				g.use(ref)
+			}
+			property = property.Items
+		}
+	}
+}
+
+func (g *generator) writeEnums(out *bytes.Buffer) {
+	for _, name := range sortedKeys(g.spec.Components.Schemas) {
+		s := g.spec.Components.Schemas[name]
+		if len(s.Enum) == 0 {
+			continue
+		}
+		goName := g.goName(name)
+		fmt.Fprintf(out, "// %sValues are the values of %s accepted by the Aura API\nvar %sValues = []string{", goName, goName, goName)
+		for _, value := range s.Enum {
+			fmt.Fprintf(out, "%q,", value)
+		}
+		out.WriteString("}\n\n")
+	}
+}
+
+func (g *generator) writeTypes(out *bytes.Buffer) error {
+	for _, name := range sortedKeys(g.spec.Components.Schemas) {
+		s := g.spec.Components.Schemas[name]
+		if !g.used[name] || s.Type != "object" {
+			continue
+		}
+		goName := g.goName(name)
+		if s.Description != "" {
+			fmt.Fprintf(out, "// %s is %s\n", goName, lowerFirst(s.Description))
+		}
+		if len(s.Properties) == 0 {
+			fmt.Fprintf(out, "type %s struct{}\n\n", goName)
+			continue
+		}
+		fmt.Fprintf(out, "type %s struct {\n", goName)
+		for _, property := range sortedKeys(s.Properties) {
+			goType, err := g.goType(s.Properties[property])
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", name, property, err)
+			}
+			tag := property
+			if !slices.Contains(s.Required, property) {
+				tag += ",omitempty"
+				if !strings.HasPrefix(goType, "[]") {
+					goType = "*" + goType
+				}
+			}
+			fmt.Fprintf(out, "%s %s `json:%q`\n", exported(property), goType, tag)
+		}
+		out.WriteString("}\n\n")
+	}
+	return nil
+}
+
+func (g *generator) goType(s *schema) (string, error) {
+	if name := schemaName(s); name != "" {
+		referenced, ok := g.spec.Components.Schemas[name]
+		if !ok {
+			return "", fmt.Errorf("unknown schema %s", s.Ref)
+		}
+		if referenced.Type == "object" {
+			return g.goName(name), nil
+		}
+		return g.goType(referenced)
+	}
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	case "number":
+		return "float64", nil
+	case "integer":
+		switch s.Format {
+		case "int32", "int64":
+			return s.Format, nil
+		default:
+			return "int", nil
+		}
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		item, err := g.goType(s.Items)
+		return "[]" + item, err
+	default:
+		return "", fmt.Errorf("unsupported type %q", s.Type)
+	}
+}
+
+// goName returns the Go type name of the schema
+func (g *generator) goName(name string) string {
+	if goName, ok := g.names[name]; ok {
+		return goName
+	}
+	return name
+}
+
+func (e endpoint) write(out *bytes.Buffer) {
+	fmt.Fprintf(out, "// %s %s\n", e.name, lowerFirst(e.summary))
+	fmt.Fprintf(out, "func %s(ctx context.Context, c *AuraClient", e.name)
+	for _, param := range append(slices.Clone(e.pathParams), e.queryParams...) {
+		fmt.Fprintf(out, ", %s string", param)
+	}
+	requestType, body := "noBody", "nil"
+	if e.request != "" {
+		requestType = e.request
+		if e.emptyRequest {
+			body = "&" + e.request + "{}"
+		} else {
+			body = "request"
+			fmt.Fprintf(out, ", request *%s", e.request)
+		}
+	}
+	fmt.Fprintf(out, ") (%s, error) {\n", e.response)
+
+	fmt.Fprintf(out, "p := %s\n", e.pathExpression())
+	if len(e.queryParams) > 0 {
+		out.WriteString("query := url.Values{}\n")
+		for _, param := range e.queryParams {
+			fmt.Fprintf(out, "if %s != \"\" {\nquery.Set(%q, %s)\n}\n", param, param, param)
+		}
+		out.WriteString("if len(query) > 0 {\np += \"?\" + query.Encode()\n}\n")
+	}
+
+	statuses := make([]string, len(e.statuses))
+	for i, status := range e.statuses {
+		statuses[i] = httpStatus(status)
+	}
+	fmt.Fprintf(out, "return call[%s, %s](withOperation(ctx, %q), c, %s, p, %s, %s)\n}\n\n",
+		requestType, e.response, e.name, e.method, body, strings.Join(statuses, ", "))
+}
+
+// pathExpression returns the Go expression of the path, with its parameters escaped
+func (e endpoint) pathExpression() string {
+	var parts []string
+	literal := ""
+	for i, segment := range strings.Split(e.path, "/") {
+		if i > 0 {
+			literal += "/"
+		}
+		if !strings.HasPrefix(segment, "{") {
+			literal += segment
+			continue
+		}
+		if literal != "" {
+			parts = append(parts, fmt.Sprintf("%q", literal))
+			literal = ""
+		}
+		parts = append(parts, "url.PathEscape("+strings.Trim(segment, "{}")+")")
+	}
+	if literal != "" {
+		parts = append(parts, fmt.Sprintf("%q", literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func (e endpoint) usesUrl() bool {
+	return len(e.pathParams) > 0 || len(e.queryParams) > 0
+}
+
+var httpStatuses = map[string]string{
+	"200": "http.StatusOK",
+	"201": "http.StatusCreated",
+	"202": "http.StatusAccepted",
+	"204": "http.StatusNoContent",
+}
+
+func httpStatus(status string) string {
+	if name, ok := httpStatuses[status]; ok {
+		return name
+	}
+	return status
+}
+
+func schemaName(s *schema) string {
+	if s == nil || s.Ref == "" {
+		return ""
+	}
+	return path.Base(s.Ref)
+}
+
+// exported converts a snake_case property name to the Go field name, e.g. tenant_id to TenantId
+func exported(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+func lowerFirst(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToLower(text[:1]) + text[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedClientIsUpToDate(t *testing.T) {
+	for spec, generated := range map[string]string{
+		"aura-v1.json": "aura_generated.go",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("../client/openapi", spec))
+			require.NoError(t, err)
+			expected, err := generate(content, spec, "client", nil)
+			require.NoError(t, err)
+
+			generatedPath := filepath.Join("../client", generated)
+			actual, err := os.ReadFile(generatedPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual), "%s is outdated, run make generate", generatedPath)
+		})
+	}
+}
+
+func TestPaginatedOperationsHaveNoStub(t *testing.T) {
+	spec := []byte(`{
+		"paths": {
+			"/items": {"get": {"operationId": "listItems", "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Page"}}}}}}},
+			"/items/{itemId}": {"get": {"operationId": "getItem",
+				"parameters": [{"name": "itemId", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}}}}}
+		},
+		"components": {"schemas": {
+			"Page": {"type": "object", "required": ["data"], "properties": {
+				"data": {"type": "array", "items": {"$ref": "#/components/schemas/Item"}}, "next": {"type": "string"}}},
+			"Item": {"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}, "size_gb": {"type": "integer", "format": "int64"}}},
+			"Unused": {"type": "object", "properties": {"id": {"type": "string"}}}
+		}}
+	}`)
+
+	generated, err := generate(spec, "items.json", "items", nil)
+	require.NoError(t, err)
+	code := string(generated)
+	assert.Contains(t, code, "type Page struct")
+	assert.Contains(t, code, "SizeGb *int64 `json:\"size_gb,omitempty\"`")
+	assert.Contains(t, code, `p := "items/" + url.PathEscape(itemId)`)
+	assert.NotContains(t, code, "func listItems")
+	assert.NotContains(t, code, "type Unused")
+}
+
+func TestSchemaNamesAreMapped(t *testing.T) {
+	spec := []byte(`{
+		"paths": {
+			"/items": {"post": {"operationId": "createItem",
+				"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/item-request"}}}},
+				"responses": {"201": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/item-response"}}}}}}}
+		},
+		"components": {"schemas": {
+			"item-request": {"type": "object", "properties": {"kind": {"$ref": "#/components/schemas/item-kind"}}},
+			"item-response": {"type": "object", "required": ["data"], "properties": {"data": {"$ref": "#/components/schemas/item-request"}}},
+			"item-kind": {"type": "string", "enum": ["small", "large"]}
+		}}
+	}`)
+	names := map[string]string{"item-request": "ItemRequest", "item-response": "ItemResponse", "item-kind": "ItemKind"}
+
+	generated, err := generate(spec, "items.json", "items", names)
+	require.NoError(t, err)
+	code := string(generated)
+	assert.Contains(t, code, "var ItemKindValues = []string{")
+	assert.Contains(t, code, "type ItemRequest struct")
+	assert.Contains(t, code, "Data ItemRequest `json:\"data\"`")
+	assert.Contains(t, code, "func createItem(ctx context.Context, c *AuraClient, request *ItemRequest) (ItemResponse, error)")
+
+	_, err = generate(spec, "items.json", "items", map[string]string{"missing": "Missing"})
+	assert.ErrorContains(t, err, "unknown schema missing")
+}
@@ -0,0 +1,195 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	envClientId     = "NEO4J_AURA_CLIENT_ID"
+	envClientSecret = "NEO4J_AURA_CLIENT_SECRET"
+	envAccessToken  = "NEO4J_AURA_ACCESS_TOKEN"
+	envProfile      = "NEO4J_AURA_PROFILE"
+)
+
+// credentials are the resolved means of authenticating to the Aura API.
+// Either the client id and secret or the access token are set.
+type credentials struct {
+	ClientId     string
+	ClientSecret string
+	AccessToken  string
+}
+
+// auraCliConfig is the credentials file written by the aura-cli
+type auraCliConfig struct {
+	Aura struct {
+		Credentials       []auraCliCredential `json:"credentials"`
+		DefaultCredential string              `json:"default-credential"`
+	} `json:"aura"`
+}
+
+type auraCliCredential struct {
+	Name         string `json:"name"`
+	ClientId     string `json:"client-id"`
+	ClientSecret string `json:"client-secret"`
+}
+
+// resolveCredentials resolves the credentials from, in order, the access_token attribute, the client_id and
+// client_secret or client_secret_file attributes, the NEO4J_AURA_CLIENT_ID and NEO4J_AURA_CLIENT_SECRET environment
+// variables, the credentials file profile set with profile, NEO4J_AURA_PROFILE or credentials_file, the
+// NEO4J_AURA_ACCESS_TOKEN environment variable and the default profile of the aura-cli credentials file.
+// The client id and secret always come from the same source, a source setting only one of them is an error.
+func resolveCredentials(data Neo4jAuraProviderModel) (credentials, error) {
+	if accessToken := stringValue(data.AccessToken); accessToken != "" {
+		return credentials{AccessToken: accessToken}, nil
+	}
+
+	clientId := stringValue(data.ClientId)
+	clientSecret := stringValue(data.ClientSecret)
+	if clientSecret == "" && stringValue(data.ClientSecretFile) != "" {
+		content, err := os.ReadFile(stringValue(data.ClientSecretFile))
+		if err != nil {
+			return credentials{}, fmt.Errorf("cannot read client_secret_file: %w", err)
+		}
+		clientSecret = strings.TrimSpace(string(content))
+	}
+	if resolved, found, err := clientCredentials(clientId, clientSecret, "client_id", "client_secret or client_secret_file"); found || err != nil {
+		return resolved, err
+	}
+
+	if resolved, found, err := clientCredentials(os.Getenv(envClientId), os.Getenv(envClientSecret), envClientId, envClientSecret); found || err != nil {
+		return resolved, err
+	}
+
+	profileName := firstNonEmpty(stringValue(data.Profile), os.Getenv(envProfile))
+	if profileName != "" || stringValue(data.CredentialsFile) != "" {
+		profile, err := loadProfile(stringValue(data.CredentialsFile), profileName)
+		if err != nil || profile != nil {
+			return profileCredentials(profile, err)
+		}
+	}
+
+	if accessToken := os.Getenv(envAccessToken); accessToken != "" {
+		return credentials{AccessToken: accessToken}, nil
+	}
+
+	// The default profile is picked up implicitly, so it comes last
+	if profile, err := loadProfile("", ""); err != nil || profile != nil {
+		return profileCredentials(profile, err)
+	}
+	return credentials{}, errors.New("no Aura API credentials found. Set access_token, client_id and client_secret, the " +
+		envClientId + " and " + envClientSecret + " environment variables, an aura-cli credentials file profile or the " +
+		envAccessToken + " environment variable")
+}
+
+// hasUnknownCredentials returns whether any of the attributes the credentials are resolved from is unknown
+func hasUnknownCredentials(data Neo4jAuraProviderModel) bool {
+	for _, value := range []types.String{data.AccessToken, data.ClientId, data.ClientSecret, data.ClientSecretFile,
+		data.Profile, data.CredentialsFile} {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+func profileCredentials(profile *auraCliCredential, err error) (credentials, error) {
+	if err != nil {
+		return credentials{}, err
+	}
+	resolved, _, err := clientCredentials(profile.ClientId, profile.ClientSecret,
+		fmt.Sprintf("the client-id of profile %q", profile.Name), fmt.Sprintf("the client-secret of profile %q", profile.Name))
+	return resolved, err
+}
+
+// clientCredentials returns the client id and secret of a source, and whether the source sets any of them.
+// A source setting only one of them is an error, rather than completing it with another source.
+func clientCredentials(clientId, clientSecret, clientIdName, clientSecretName string) (credentials, bool, error) {
+	switch {
+	case clientId == "" && clientSecret == "":
+		return credentials{}, false, nil
+	case clientId == "":
+		return credentials{}, true, fmt.Errorf("%s is set without %s", clientSecretName, clientIdName)
+	case clientSecret == "":
+		return credentials{}, true, fmt.Errorf("%s is set without %s", clientIdName, clientSecretName)
+	}
+	return credentials{ClientId: clientId, ClientSecret: clientSecret}, true, nil
+}
+
+// loadProfile returns the named credential of the aura-cli credentials file, or its default credential when
+// name is empty. A missing file is only an error when its path was configured explicitly.
+func loadProfile(path string, name string) (*auraCliCredential, error) {
+	explicitPath := path != ""
+	if !explicitPath {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, nil
+		}
+		path = filepath.Join(configDir, "neo4j", "cli", "credentials.json")
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicitPath && name == "" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read credentials file: %w", err)
+	}
+	var config auraCliConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("cannot parse credentials file %s: %w", path, err)
+	}
+
+	if name == "" {
+		name = config.Aura.DefaultCredential
+	}
+	for _, credential := range config.Aura.Credentials {
+		if credential.Name == name {
+			return &credential, nil
+		}
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("profile %q not found in credentials file %s", name, path)
+}
+
+func stringValue(value types.String) string {
+	if value.IsUnknown() || value.IsNull() {
+		return ""
+	}
+	return value.ValueString()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
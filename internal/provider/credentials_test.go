@@ -0,0 +1,165 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCredentialsFile = `{
+  "aura": {
+    "credentials": [
+      {"name": "ci", "client-id": "ci-id", "client-secret": "ci-secret"},
+      {"name": "dev", "client-id": "dev-id", "client-secret": "dev-secret"}
+    ],
+    "default-credential": "dev"
+  }
+}`
+
+func TestResolveCredentials(t *testing.T) {
+	dir := t.TempDir()
+	credentialsFile := filepath.Join(dir, "credentials.json")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte(testCredentialsFile), 0o600))
+	secretFile := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0o600))
+
+	cases := map[string]struct {
+		data Neo4jAuraProviderModel
+		env  map[string]string
+		// defaultCredentialsFile writes the aura-cli credentials file of the user
+		defaultCredentialsFile bool
+		expected               credentials
+	}{
+		"attributes win over the environment": {
+			data:     Neo4jAuraProviderModel{ClientId: types.StringValue("id"), ClientSecret: types.StringValue("secret")},
+			env:      map[string]string{envClientId: "env-id", envClientSecret: "env-secret"},
+			expected: credentials{ClientId: "id", ClientSecret: "secret"},
+		},
+		"environment": {
+			env:      map[string]string{envClientId: "env-id", envClientSecret: "env-secret"},
+			expected: credentials{ClientId: "env-id", ClientSecret: "env-secret"},
+		},
+		"client secret file": {
+			data:     Neo4jAuraProviderModel{ClientId: types.StringValue("id"), ClientSecretFile: types.StringValue(secretFile)},
+			env:      map[string]string{envClientId: "env-id", envClientSecret: "env-secret"},
+			expected: credentials{ClientId: "id", ClientSecret: "file-secret"},
+		},
+		"default profile": {
+			data:     Neo4jAuraProviderModel{CredentialsFile: types.StringValue(credentialsFile)},
+			expected: credentials{ClientId: "dev-id", ClientSecret: "dev-secret"},
+		},
+		"named profile": {
+			data:     Neo4jAuraProviderModel{CredentialsFile: types.StringValue(credentialsFile), Profile: types.StringValue("ci")},
+			expected: credentials{ClientId: "ci-id", ClientSecret: "ci-secret"},
+		},
+		"access token": {
+			data:     Neo4jAuraProviderModel{AccessToken: types.StringValue("token")},
+			expected: credentials{AccessToken: "token"},
+		},
+		"access token attribute wins over the environment and the default profile": {
+			data:                   Neo4jAuraProviderModel{AccessToken: types.StringValue("token")},
+			env:                    map[string]string{envClientId: "env-id", envClientSecret: "env-secret"},
+			defaultCredentialsFile: true,
+			expected:               credentials{AccessToken: "token"},
+		},
+		"client credentials of the environment win over the access token of the environment": {
+			env:      map[string]string{envClientId: "env-id", envClientSecret: "env-secret", envAccessToken: "env-token"},
+			expected: credentials{ClientId: "env-id", ClientSecret: "env-secret"},
+		},
+		"access token of the environment wins over the default profile": {
+			env:                    map[string]string{envAccessToken: "env-token"},
+			defaultCredentialsFile: true,
+			expected:               credentials{AccessToken: "env-token"},
+		},
+		"implicit default profile": {
+			defaultCredentialsFile: true,
+			expected:               credentials{ClientId: "dev-id", ClientSecret: "dev-secret"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			configDir := isolateCredentialsEnv(t)
+			if tc.defaultCredentialsFile {
+				writeDefaultCredentialsFile(t, configDir)
+			}
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			resolved, err := resolveCredentials(tc.data)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, resolved)
+		})
+	}
+}
+
+func TestResolveCredentialsErrors(t *testing.T) {
+	isolateCredentialsEnv(t)
+
+	_, err := resolveCredentials(Neo4jAuraProviderModel{})
+	assert.ErrorContains(t, err, "no Aura API credentials found")
+
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte(testCredentialsFile), 0o600))
+	_, err = resolveCredentials(Neo4jAuraProviderModel{CredentialsFile: types.StringValue(credentialsFile), Profile: types.StringValue("prod")})
+	assert.ErrorContains(t, err, `profile "prod" not found`)
+
+	_, err = resolveCredentials(Neo4jAuraProviderModel{ClientSecretFile: types.StringValue(filepath.Join(t.TempDir(), "missing"))})
+	assert.ErrorContains(t, err, "cannot read client_secret_file")
+}
+
+func TestResolveCredentialsDoesNotMixSources(t *testing.T) {
+	configDir := isolateCredentialsEnv(t)
+	writeDefaultCredentialsFile(t, configDir)
+	t.Setenv(envClientSecret, "env-secret")
+
+	_, err := resolveCredentials(Neo4jAuraProviderModel{ClientId: types.StringValue("id")})
+	assert.ErrorContains(t, err, "client_id is set without client_secret or client_secret_file")
+
+	_, err = resolveCredentials(Neo4jAuraProviderModel{})
+	assert.ErrorContains(t, err, envClientSecret+" is set without "+envClientId)
+}
+
+// isolateCredentialsEnv clears the credentials environment variables and hides the credentials file of the user,
+// returning the config directory replacing the one of the user
+func isolateCredentialsEnv(t *testing.T) string {
+	for _, key := range []string{envClientId, envClientSecret, envAccessToken, envProfile} {
+		t.Setenv(key, "")
+	}
+	configDir := t.TempDir()
+	t.Setenv("HOME", configDir)
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("AppData", configDir)
+	return configDir
+}
+
+func writeDefaultCredentialsFile(t *testing.T, configDir string) {
+	userConfigDir, err := os.UserConfigDir()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(userConfigDir, configDir))
+	dir := filepath.Join(userConfigDir, "neo4j", "cli")
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "credentials.json"), []byte(testCredentialsFile), 0o600))
+}
@@ -19,26 +19,58 @@ package provider
 
 import (
 	"context"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
 	auradatasource "github.com/neo4j-labs/terraform-provider-neo4jaura/internal/datasource"
 	auraresource "github.com/neo4j-labs/terraform-provider-neo4jaura/internal/resource"
 )
 
+const (
+	envBaseUrl     = "NEO4J_AURA_BASE_URL"
+	envTokenCache  = "NEO4J_AURA_TOKEN_CACHE"
+	envHttpCapture = "NEO4J_AURA_HTTP_CAPTURE"
+)
+
 type Neo4jAuraProvider struct {
-	version string
+	version   string
+	telemetry *client.Telemetry
 }
 
 type Neo4jAuraProviderModel struct {
-	ClientId        types.String `tfsdk:"client_id"`
-	ClientSecret    types.String `tfsdk:"client_secret"`
-	InstanceTimeout types.Int64  `tfsdk:"instance_timeout"`
-	SnapshotTimeout types.Int64  `tfsdk:"snapshot_timeout"`
+	ClientId              types.String  `tfsdk:"client_id"`
+	ClientSecret          types.String  `tfsdk:"client_secret"`
+	ClientSecretFile      types.String  `tfsdk:"client_secret_file"`
+	CredentialsFile       types.String  `tfsdk:"credentials_file"`
+	Profile               types.String  `tfsdk:"profile"`
+	AccessToken           types.String  `tfsdk:"access_token"`
+	InstanceTimeout       types.Int64   `tfsdk:"instance_timeout"`
+	SnapshotTimeout       types.Int64   `tfsdk:"snapshot_timeout"`
+	BaseUrl               types.String  `tfsdk:"base_url"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	MinRetryWait          types.Int64   `tfsdk:"min_retry_wait"`
+	MaxRetryWait          types.Int64   `tfsdk:"max_retry_wait"`
+	TokenCache            types.Bool    `tfsdk:"token_cache"`
+	ProxyUrl              types.String  `tfsdk:"proxy_url"`
+	CaCertFile            types.String  `tfsdk:"ca_cert_file"`
+	ClientCertFile        types.String  `tfsdk:"client_cert_file"`
+	ClientKeyFile         types.String  `tfsdk:"client_key_file"`
+	RequestTimeout        types.Int64   `tfsdk:"request_timeout"`
+	TotalRequestTimeout   types.Int64   `tfsdk:"total_request_timeout"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
 }
 
 func (n *Neo4jAuraProvider) Metadata(ctx context.Context, request provider.MetadataRequest, response *provider.MetadataResponse) {
@@ -48,17 +80,49 @@ func (n *Neo4jAuraProvider) Metadata(ctx context.Context, request provider.Metad
 
 func (n *Neo4jAuraProvider) Schema(ctx context.Context, request provider.SchemaRequest, response *provider.SchemaResponse) {
 	response.Schema = schema.Schema{
+		MarkdownDescription: "The credentials are resolved, in order, from the `access_token` attribute, the `client_id` and " +
+			"`client_secret` or `client_secret_file` attributes, the `" + envClientId + "` and `" + envClientSecret + "` " +
+			"environment variables, the aura-cli credentials file profile selected with `profile`, `" + envProfile + "` or " +
+			"`credentials_file`, the `" + envAccessToken + "` environment variable and the default profile of the aura-cli " +
+			"credentials file of the user. The client id and secret are never combined from different sources.\n\n" +
+			"Set the `" + envHttpCapture + "` environment variable to a file path to record the Aura API requests and responses, " +
+			"with credentials and passwords redacted, in JSON Lines format.\n\n" +
+			"The count, latency and retries of every Aura API operation and the duration of the waits for instances and " +
+			"snapshots are logged when the provider shuts down. Set the `OTEL_EXPORTER_OTLP_ENDPOINT` or " +
+			"`OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` environment variable to also export them as OpenTelemetry spans, " +
+			"over OTLP/HTTP.",
 		Attributes: map[string]schema.Attribute{
 			"client_id": schema.StringAttribute{
-				Description:         "Aura Client ID",
-				MarkdownDescription: "Aura Client ID",
-				Required:            true,
+				Description:         "Aura Client ID. Can also be set with the " + envClientId + " environment variable",
+				MarkdownDescription: "Aura Client ID. Can also be set with the `" + envClientId + "` environment variable",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"client_secret": schema.StringAttribute{
-				Description:         "Aura Client Secret",
-				MarkdownDescription: "Aura Client Secret",
-				Required:            true,
+				Description:         "Aura Client Secret. Can also be set with the " + envClientSecret + " environment variable",
+				MarkdownDescription: "Aura Client Secret. Can also be set with the `" + envClientSecret + "` environment variable",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"client_secret_file": schema.StringAttribute{
+				Description:         "Path of a file containing the Aura Client Secret. Used with client_id when client_secret isn't set",
+				MarkdownDescription: "Path of a file containing the Aura Client Secret. Used with `client_id` when `client_secret` isn't set",
+				Optional:            true,
+			},
+			"credentials_file": schema.StringAttribute{
+				Description:         "Path of an aura-cli credentials file, used when the client id and secret aren't set otherwise. Defaults to the aura-cli credentials file in the user config directory",
+				MarkdownDescription: "Path of an aura-cli credentials file, used when the client id and secret aren't set otherwise. Defaults to the aura-cli credentials file in the user config directory",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				Description:         "Name of the credentials file profile. Can also be set with the " + envProfile + " environment variable. Defaults to the default credential of the file",
+				MarkdownDescription: "Name of the credentials file profile. Can also be set with the `" + envProfile + "` environment variable. Defaults to the default credential of the file",
+				Optional:            true,
+			},
+			"access_token": schema.StringAttribute{
+				Description:         "Pre-issued Aura API bearer token, used instead of any client credentials when set. Can also be set with the " + envAccessToken + " environment variable",
+				MarkdownDescription: "Pre-issued Aura API bearer token, used instead of any client credentials when set. Can also be set with the `" + envAccessToken + "` environment variable",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"instance_timeout": schema.Int64Attribute{
@@ -71,6 +135,98 @@ func (n *Neo4jAuraProvider) Schema(ctx context.Context, request provider.SchemaR
 				MarkdownDescription: "Timeout for snapshot operations (seconds). Defaults to 300 seconds",
 				Optional:            true,
 			},
+			"base_url": schema.StringAttribute{
+				Description:         "Base URL of the Aura API, used for both authentication and the v1 API. Can also be set with the " + envBaseUrl + " environment variable. Defaults to " + client.DefaultAuraBaseUrl,
+				MarkdownDescription: "Base URL of the Aura API, used for both authentication and the v1 API. Can also be set with the `" + envBaseUrl + "` environment variable. Defaults to `" + client.DefaultAuraBaseUrl + "`",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description:         "Maximum number of retries of a failed or rate limited Aura API request. Defaults to 5",
+				MarkdownDescription: "Maximum number of retries of a failed or rate limited Aura API request. Defaults to 5",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_retry_wait": schema.Int64Attribute{
+				Description:         "Minimum wait between retries of an Aura API request (seconds). Defaults to 1 second",
+				MarkdownDescription: "Minimum wait between retries of an Aura API request (seconds). Defaults to 1 second",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_retry_wait": schema.Int64Attribute{
+				Description:         "Maximum wait between retries of an Aura API request (seconds), unless Aura asks for a longer wait with a Retry-After header. Defaults to 30 seconds",
+				MarkdownDescription: "Maximum wait between retries of an Aura API request (seconds), unless Aura asks for a longer wait with a `Retry-After` header. Defaults to 30 seconds",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"token_cache": schema.BoolAttribute{
+				Description:         "Cache the Aura API token in the user cache directory, so that it is reused by the provider processes Terraform starts for every command. Can also be set with the " + envTokenCache + " environment variable. Defaults to false",
+				MarkdownDescription: "Cache the Aura API token in the user cache directory, so that it is reused by the provider processes Terraform starts for every command. Can also be set with the `" + envTokenCache + "` environment variable. Defaults to `false`",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description:         "URL of the proxy used to reach the Aura API. Defaults to the proxy of the HTTPS_PROXY and NO_PROXY environment variables",
+				MarkdownDescription: "URL of the proxy used to reach the Aura API. Defaults to the proxy of the `HTTPS_PROXY` and `NO_PROXY` environment variables",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description:         "Path of a PEM bundle of certificate authorities to trust in addition to the system ones, e.g. the one of a TLS-intercepting proxy",
+				MarkdownDescription: "Path of a PEM bundle of certificate authorities to trust in addition to the system ones, e.g. the one of a TLS-intercepting proxy",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description:         "Path of a PEM client certificate presented to the Aura API or the proxy. Requires client_key_file",
+				MarkdownDescription: "Path of a PEM client certificate presented to the Aura API or the proxy. Requires `client_key_file`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_file")),
+				},
+			},
+			"client_key_file": schema.StringAttribute{
+				Description:         "Path of the PEM private key of the client certificate. Requires client_cert_file",
+				MarkdownDescription: "Path of the PEM private key of the client certificate. Requires `client_cert_file`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_file")),
+				},
+			},
+			"request_timeout": schema.Int64Attribute{
+				Description:         "Timeout of a single HTTP request to the Aura API (seconds). Defaults to no timeout",
+				MarkdownDescription: "Timeout of a single HTTP request to the Aura API (seconds). Defaults to no timeout",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total_request_timeout": schema.Int64Attribute{
+				Description:         "Timeout of an Aura API request including all its retries (seconds). Defaults to no timeout",
+				MarkdownDescription: "Timeout of an Aura API request including all its retries (seconds). Defaults to no timeout",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description:         "Maximum number of Aura API requests in flight at once, shared by all resources and data sources. Defaults to no limit",
+				MarkdownDescription: "Maximum number of Aura API requests in flight at once, shared by all resources and data sources. Defaults to no limit",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description:         "Maximum rate of Aura API requests, shared by all resources and data sources. Short bursts of up to one second worth of requests are allowed. Defaults to no limit",
+				MarkdownDescription: "Maximum rate of Aura API requests, shared by all resources and data sources. Short bursts of up to one second worth of requests are allowed. Defaults to no limit",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.01),
+				},
+			},
 		},
 	}
 }
@@ -84,11 +240,85 @@ func (n *Neo4jAuraProvider) Configure(ctx context.Context, request provider.Conf
 		return
 	}
 
-	auraClient := client.NewAuraClient(
-		data.ClientId.ValueString(),
-		data.ClientSecret.ValueString(),
-		n.version,
-	)
+	baseUrl := os.Getenv(envBaseUrl)
+	if !data.BaseUrl.IsUnknown() && !data.BaseUrl.IsNull() {
+		baseUrl = data.BaseUrl.ValueString()
+	}
+
+	// The credentials can depend on values only known after apply, e.g. the outputs of another resource. The client
+	// is configured without credentials until then, rather than failing the plan.
+	var credentials credentials
+	if hasUnknownCredentials(data) {
+		response.Diagnostics.AddWarning("Aura API credentials are not known yet",
+			"The credentials of the provider depend on values known after apply. The Aura API requests made before "+
+				"they are known fail to authenticate.")
+	} else {
+		resolved, err := resolveCredentials(data)
+		if err != nil {
+			response.Diagnostics.AddError("Missing Aura API credentials", err.Error())
+			return
+		}
+		credentials = resolved
+	}
+
+	clientConfig := client.AuraClientConfig{
+		ClientId:     credentials.ClientId,
+		ClientSecret: credentials.ClientSecret,
+		AccessToken:  credentials.AccessToken,
+		BaseUrl:      baseUrl,
+		Version:      n.version,
+	}
+	if !data.MaxRetries.IsUnknown() && !data.MaxRetries.IsNull() {
+		maxRetries := int(data.MaxRetries.ValueInt64())
+		clientConfig.MaxRetries = &maxRetries
+	}
+	if !data.MinRetryWait.IsUnknown() && !data.MinRetryWait.IsNull() {
+		minRetryWait := time.Duration(data.MinRetryWait.ValueInt64()) * time.Second
+		clientConfig.RetryWaitMin = &minRetryWait
+	}
+	if !data.MaxRetryWait.IsUnknown() && !data.MaxRetryWait.IsNull() {
+		maxRetryWait := time.Duration(data.MaxRetryWait.ValueInt64()) * time.Second
+		clientConfig.RetryWaitMax = &maxRetryWait
+	}
+	clientConfig.CaptureFile = os.Getenv(envHttpCapture)
+	if err := n.telemetry.Start(ctx, n.version); err != nil {
+		response.Diagnostics.AddWarning("OpenTelemetry export is disabled", "Cannot create the OTLP exporter: "+err.Error())
+	}
+	clientConfig.Telemetry = n.telemetry
+	if !data.MaxConcurrentRequests.IsUnknown() && !data.MaxConcurrentRequests.IsNull() {
+		clientConfig.MaxConcurrentRequests = int(data.MaxConcurrentRequests.ValueInt64())
+	}
+	if !data.RequestsPerSecond.IsUnknown() && !data.RequestsPerSecond.IsNull() {
+		clientConfig.RequestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	}
+	clientConfig.ProxyUrl = stringValue(data.ProxyUrl)
+	clientConfig.CaCertFile = stringValue(data.CaCertFile)
+	clientConfig.ClientCertFile = stringValue(data.ClientCertFile)
+	clientConfig.ClientKeyFile = stringValue(data.ClientKeyFile)
+	if !data.RequestTimeout.IsUnknown() && !data.RequestTimeout.IsNull() {
+		requestTimeout := time.Duration(data.RequestTimeout.ValueInt64()) * time.Second
+		clientConfig.RequestTimeout = &requestTimeout
+	}
+	if !data.TotalRequestTimeout.IsUnknown() && !data.TotalRequestTimeout.IsNull() {
+		totalRequestTimeout := time.Duration(data.TotalRequestTimeout.ValueInt64()) * time.Second
+		clientConfig.Timeout = &totalRequestTimeout
+	}
+	tokenCache, _ := strconv.ParseBool(os.Getenv(envTokenCache))
+	if !data.TokenCache.IsUnknown() && !data.TokenCache.IsNull() {
+		tokenCache = data.TokenCache.ValueBool()
+	}
+	if tokenCache {
+		tokenCacheDir, err := client.DefaultTokenCacheDir()
+		if err != nil {
+			response.Diagnostics.AddWarning("Token cache is disabled", "Cannot find the user cache directory: "+err.Error())
+		}
+		clientConfig.TokenCacheDir = tokenCacheDir
+	}
+	auraClient, err := client.NewAuraClient(clientConfig)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid Aura API client configuration", err.Error())
+		return
+	}
 	var instanceTimeoutSec *int64
 	if !data.InstanceTimeout.IsUnknown() && !data.InstanceTimeout.IsNull() {
 		instanceTimeoutSec = data.InstanceTimeout.ValueInt64Pointer()
@@ -117,10 +347,16 @@ func (n *Neo4jAuraProvider) Resources(ctx context.Context) []func() resource.Res
 	}
 }
 
-func New(version string) func() provider.Provider {
+// New returns the provider factory. The telemetry is shared by every instance of the provider, so that it can be
+// summarized when the provider process shuts down. A nil telemetry is only recorded, never summarized.
+func New(version string, telemetry *client.Telemetry) func() provider.Provider {
+	if telemetry == nil {
+		telemetry = client.NewTelemetry()
+	}
 	return func() provider.Provider {
 		return &Neo4jAuraProvider{
-			version: version,
+			version:   version,
+			telemetry: telemetry,
 		}
 	}
 }
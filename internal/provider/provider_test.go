@@ -0,0 +1,62 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefaultsTheTelemetry(t *testing.T) {
+	t.Parallel()
+
+	auraProvider := New("test", nil)().(*Neo4jAuraProvider)
+	assert.NotNil(t, auraProvider.telemetry)
+}
+
+func TestConfigureToleratesUnknownCredentials(t *testing.T) {
+	isolateCredentialsEnv(t)
+	ctx := context.Background()
+	auraProvider := New("test", nil)()
+	schemaResponse := &provider.SchemaResponse{}
+	auraProvider.Schema(ctx, provider.SchemaRequest{}, schemaResponse)
+	require.False(t, schemaResponse.Diagnostics.HasError())
+
+	configType := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range configType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["client_id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	values["client_secret"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	config := tfsdk.Config{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(configType, values)}
+
+	response := &provider.ConfigureResponse{}
+	auraProvider.Configure(ctx, provider.ConfigureRequest{Config: config}, response)
+
+	assert.False(t, response.Diagnostics.HasError(), "%v", response.Diagnostics)
+	assert.Equal(t, 1, response.Diagnostics.WarningsCount())
+	assert.NotNil(t, response.ResourceData)
+	assert.NotNil(t, response.DataSourceData)
+}
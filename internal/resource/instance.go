@@ -22,13 +22,17 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -78,8 +82,11 @@ type InstanceResourceModel struct {
 	CdcEnrichmentMode     types.String `tfsdk:"cdc_enrichment_mode"`
 	VectorOptimized       types.Bool   `tfsdk:"vector_optimized"`
 	GraphAnalyticsPlugin  types.Bool   `tfsdk:"graph_analytics_plugin"`
+	DeletionProtection    types.Bool   `tfsdk:"deletion_protection"`
+	OnDestroy             types.String `tfsdk:"on_destroy"`
 
-	Source types.Object `tfsdk:"source"`
+	Source   types.Object   `tfsdk:"source"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type InstanceResourceSourceModel struct {
@@ -99,6 +106,31 @@ func (m InstanceResourceModel) CanBeResumed() bool {
 		strings.ToLower(m.Status.ValueString()) == domain.InstanceStatusPaused
 }
 
+// withUnknownValuesAsNull returns the model with null instead of the computed values that aren't known yet, e.g. to
+// save an instance whose creation failed after Aura created it
+func (m InstanceResourceModel) withUnknownValuesAsNull() InstanceResourceModel {
+	for _, value := range []*types.String{&m.ConnectionUrl, &m.Username, &m.Password, &m.Memory, &m.Type,
+		&m.CloudProvider, &m.Version, &m.Storage, &m.Status, &m.CreatedAt, &m.MetricsIntegrationUrl, &m.CdcEnrichmentMode} {
+		if value.IsUnknown() {
+			*value = types.StringNull()
+		}
+	}
+	for _, value := range []*types.Int64{&m.GraphNodes, &m.GraphRelationships} {
+		if value.IsUnknown() {
+			*value = types.Int64Null()
+		}
+	}
+	if m.SecondariesCount.IsUnknown() {
+		m.SecondariesCount = types.Int32Null()
+	}
+	for _, value := range []*types.Bool{&m.VectorOptimized, &m.GraphAnalyticsPlugin} {
+		if value.IsUnknown() {
+			*value = types.BoolNull()
+		}
+	}
+	return m
+}
+
 func (r *InstanceResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_instance"
 }
@@ -120,7 +152,7 @@ func (r *InstanceResource) Configure(_ context.Context, request resource.Configu
 	r.auraApi = auraApi
 }
 
-func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+func (r *InstanceResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		MarkdownDescription: "Aura instance",
 		Description:         "Aura instance",
@@ -142,6 +174,9 @@ func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				MarkdownDescription: "Region of the instance",
 				Description:         "Region of the instance",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					protectedFromReplacement(stringplanmodifier.RequiresReplace()),
+				},
 			},
 			"memory": schema.StringAttribute{
 				MarkdownDescription: fmt.Sprintf("Memory allocated for the instance. One of [%s]", strings.Join(supportedMemory, ",")),
@@ -164,6 +199,7 @@ func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Default:             stringdefault.StaticString("free-db"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					protectedFromReplacement(stringplanmodifier.RequiresReplace()),
 				},
 				Validators: []validator.String{
 					stringvalidator.OneOf(supportedTypes...),
@@ -177,6 +213,7 @@ func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Default:             stringdefault.StaticString("gcp"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					protectedFromReplacement(stringplanmodifier.RequiresReplace()),
 				},
 				Validators: []validator.String{
 					stringvalidator.OneOf(supportedCloudProviders...),
@@ -186,6 +223,9 @@ func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				MarkdownDescription: "Id of the project",
 				Description:         "Id of the project",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					protectedFromReplacement(stringplanmodifier.RequiresReplace()),
+				},
 			},
 			"connection_url": schema.StringAttribute{
 				MarkdownDescription: "Bolt connection URL to the instance database",
@@ -204,8 +244,8 @@ func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password of the instance database",
-				Description:         "Password of the instance database",
+				MarkdownDescription: "Password of the instance database. Null when the instance was adopted after its creation request was interrupted, as Aura only returns it once",
+				Description:         "Password of the instance database. Null when the instance was adopted after its creation request was interrupted, as Aura only returns it once",
 				Computed:            true,
 				Sensitive:           true,
 				PlanModifiers: []planmodifier.String{
@@ -220,6 +260,9 @@ func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Default:             stringdefault.StaticString("5"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					protectedFromReplacement(stringplanmodifier.RequiresReplaceIf(requiresReplaceIfStateIsKnown,
+						"Changing the version of an instance requires a new instance",
+						"Changing the version of an instance requires a new instance")),
 				},
 				Validators: []validator.String{
 					stringvalidator.OneOf(supportedVersions...),
@@ -315,10 +358,32 @@ func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether the instance is protected from deletion. When true, destroying the instance or a change requiring to replace it fails. Defaults to false",
+				Description:         "Whether the instance is protected from deletion. When true, destroying the instance or a change requiring to replace it fails. Defaults to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("What destroying the instance does. One of [%s]. `pause` pauses the instance and removes it from the state, `snapshot_then_delete` takes a snapshot of the instance and waits for it to complete before deleting the instance. Defaults to `delete`", strings.Join(supportedOnDestroy, ", ")),
+				Description:         fmt.Sprintf("What destroying the instance does. One of [%s]. pause pauses the instance and removes it from the state, snapshot_then_delete takes a snapshot of the instance and waits for it to complete before deleting the instance. Defaults to delete", strings.Join(supportedOnDestroy, ", ")),
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(domain.InstanceOnDestroyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(supportedOnDestroy...),
+				},
+			},
 			"source": schema.SingleNestedAttribute{
 				MarkdownDescription: "Information about source for the instance",
 				Description:         "Information about source for the instance",
 				Optional:            true,
+				PlanModifiers: []planmodifier.Object{
+					protectedObjectFromReplacement(objectplanmodifier.RequiresReplaceIf(objectRequiresReplaceIfStateIsKnown,
+						"Changing the source of an instance requires a new instance",
+						"Changing the source of an instance requires a new instance")),
+				},
 				Attributes: map[string]schema.Attribute{
 					"instance_id": schema.StringAttribute{
 						MarkdownDescription: "Instance Id that contains the source database of the instance",
@@ -333,6 +398,9 @@ func (r *InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -353,6 +421,12 @@ func (r *InstanceResource) Create(ctx context.Context, request resource.CreateRe
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts.Create, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	postInstanceRequest := &client.PostInstanceRequest{
 		Version:       data.Version.ValueString(),
 		Region:        data.Region.ValueString(),
@@ -370,12 +444,11 @@ func (r *InstanceResource) Create(ctx context.Context, request resource.CreateRe
 		}
 		postInstanceRequest.SourceInstanceId = sourceData.InstanceId.ValueStringPointer()
 		if !sourceData.SnapshotId.IsNull() {
-			_, err := r.auraApi.WaitUntilSnapshotIsInState(ctx, sourceData.InstanceId.ValueString(), sourceData.SnapshotId.ValueString(),
-				func(resp client.GetSnapshotData) bool {
-					return strings.ToLower(resp.Status) == "completed"
-				})
+			_, err := r.auraApi.WaitUntilSnapshotHasStatus(ctx, sourceData.InstanceId.ValueString(), sourceData.SnapshotId.ValueString(),
+				domain.SnapshotStatusCompleted)
 			if err != nil {
-				response.Diagnostics.AddError("Error while waiting snapshot to be completed", err.Error())
+				util.AddError(&response.Diagnostics, "Error while waiting snapshot to be completed", err)
+				return
 			}
 			postInstanceRequest.SourceSnapshotId = sourceData.SnapshotId.ValueStringPointer()
 		}
@@ -394,11 +467,24 @@ func (r *InstanceResource) Create(ctx context.Context, request resource.CreateRe
 		postInstanceRequest.GraphAnalyticsPlugin = data.GraphAnalyticsPlugin.ValueBoolPointer()
 	}
 
-	postInstanceResp, err := r.auraApi.PostInstance(ctx, *postInstanceRequest)
+	postInstanceResp, adopted, err := r.auraApi.CreateInstance(ctx, *postInstanceRequest)
 	if err != nil {
-		response.Diagnostics.AddError("Error while creating an instance", err.Error())
+		util.AddError(&response.Diagnostics, "Error while creating an instance", err)
 		return
 	}
+	if adopted {
+		response.Diagnostics.AddWarning("Adopted an instance created by an interrupted request",
+			fmt.Sprintf("The request creating the instance failed after Aura created instance %s, which is now managed "+
+				"by Terraform instead of creating a duplicate. Its password was only returned by the failed request: "+
+				"reset it in the Aura console.", postInstanceResp.Data.Id))
+	}
+
+	// The password is only returned on creation, so make sure no later log of this operation leaks it
+	ctx = client.MaskSecrets(ctx)
+	if postInstanceResp.Data.Password != "" {
+		ctx = tflog.MaskMessageStrings(ctx, postInstanceResp.Data.Password)
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, postInstanceResp.Data.Password)
+	}
 
 	requestedStatus := data.Status
 
@@ -406,14 +492,19 @@ func (r *InstanceResource) Create(ctx context.Context, request resource.CreateRe
 	data.ConnectionUrl = types.StringValue(postInstanceResp.Data.ConnectionUrl)
 	data.Username = types.StringValue(postInstanceResp.Data.Username)
 	data.Password = types.StringValue(postInstanceResp.Data.Password)
+	if adopted {
+		data.Username = types.StringNull()
+		data.Password = types.StringNull()
+	}
 
 	tflog.Debug(ctx, "Created an instance with id "+postInstanceResp.Data.Id)
 
-	instance, err := r.auraApi.WaitUntilInstanceIsInState(ctx, postInstanceResp.Data.Id, func(r client.GetInstanceResponse) bool {
-		return strings.ToLower(r.Data.Status) == domain.InstanceStatusRunning
-	})
+	instance, err := r.auraApi.WaitUntilInstanceHasStatus(ctx, postInstanceResp.Data.Id, domain.InstanceStatusRunning)
 	if err != nil {
-		response.Diagnostics.AddError("Instance is not running in time", err.Error())
+		util.AddError(&response.Diagnostics, "Instance is not running in time", err)
+		// Keep track of the created instance, Terraform marks it as tainted and replaces it on the next apply
+		response.Diagnostics.Append(response.State.Set(ctx, data.withUnknownValuesAsNull())...)
+		return
 	}
 
 	// CDC enrichment mode and secondaries_count must be set via PATCH after instance creation
@@ -435,14 +526,14 @@ func (r *InstanceResource) Create(ctx context.Context, request resource.CreateRe
 		}
 		instance, err = r.auraApi.PatchInstanceById(ctx, postInstanceResp.Data.Id, patchRequest)
 		if err != nil {
-			response.Diagnostics.AddError("Error while patching instance (CDC / secondaries_count)", err.Error())
+			util.AddError(&response.Diagnostics, "Error while patching instance (CDC / secondaries_count)", err)
+			response.Diagnostics.Append(response.State.Set(ctx, data.withUnknownValuesAsNull())...)
 			return
 		}
-		instance, err = r.auraApi.WaitUntilInstanceIsInState(ctx, postInstanceResp.Data.Id, func(r client.GetInstanceResponse) bool {
-			return strings.ToLower(r.Data.Status) == domain.InstanceStatusRunning
-		})
+		instance, err = r.auraApi.WaitUntilInstanceHasStatus(ctx, postInstanceResp.Data.Id, domain.InstanceStatusRunning)
 		if err != nil {
-			response.Diagnostics.AddError("Instance is not running after PATCH", err.Error())
+			util.AddError(&response.Diagnostics, "Instance is not running after PATCH", err)
+			response.Diagnostics.Append(response.State.Set(ctx, data.withUnknownValuesAsNull())...)
 			return
 		}
 		tflog.Debug(ctx, fmt.Sprintf("Successfully patched instance %s", postInstanceResp.Data.Id))
@@ -529,9 +620,20 @@ func (r *InstanceResource) Read(ctx context.Context, request resource.ReadReques
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, stateData.Timeouts.Read, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	instance, err := r.auraApi.GetInstanceById(ctx, stateData.InstanceId.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Instance %s no longer exists, removing it from state", stateData.InstanceId.ValueString()))
+		response.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		response.Diagnostics.AddError("Error while getting instance details", err.Error())
+		util.AddError(&response.Diagnostics, "Error while getting instance details", err)
 		return
 	}
 
@@ -540,6 +642,9 @@ func (r *InstanceResource) Read(ctx context.Context, request resource.ReadReques
 	stateData.Memory = types.StringValue(instance.Data.Memory)
 	stateData.Type = types.StringValue(instance.Data.Type)
 	stateData.CloudProvider = types.StringValue(instance.Data.CloudProvider)
+	if instance.Data.TenantId != "" {
+		stateData.ProjectId = types.StringValue(instance.Data.TenantId)
+	}
 	stateData.ConnectionUrl = types.StringValue(instance.Data.ConnectionUrl)
 	if instance.Data.Storage != nil {
 		stateData.Storage = types.StringValue(*instance.Data.Storage)
@@ -595,6 +700,13 @@ func (r *InstanceResource) Read(ctx context.Context, request resource.ReadReques
 	} else {
 		stateData.GraphAnalyticsPlugin = types.BoolNull()
 	}
+	// Deletion protection and the destroy behavior only exist in the state, they have their defaults after an import
+	if stateData.DeletionProtection.IsNull() {
+		stateData.DeletionProtection = types.BoolValue(false)
+	}
+	if stateData.OnDestroy.IsNull() {
+		stateData.OnDestroy = types.StringValue(domain.InstanceOnDestroyDelete)
+	}
 
 	response.Diagnostics.Append(response.State.Set(ctx, &stateData)...)
 }
@@ -613,6 +725,12 @@ func (r *InstanceResource) Update(ctx context.Context, request resource.UpdateRe
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Update, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	// Resume
 	if strings.ToLower(plan.Status.ValueString()) == domain.InstanceStatusRunning && state.CanBeResumed() {
 		diagError := r.resumeInstance(ctx, state.InstanceId.ValueString())
@@ -622,40 +740,33 @@ func (r *InstanceResource) Update(ctx context.Context, request resource.UpdateRe
 		}
 	}
 
-	// Regular inplace update (name, memory, secondaries_count)
-	planNameOrMemoryChanged := !plan.Name.Equal(state.Name) || !plan.Memory.Equal(state.Memory)
-	planSecondariesChanged := !plan.SecondariesCount.Equal(state.SecondariesCount)
-	if planNameOrMemoryChanged || planSecondariesChanged {
-		tflog.Debug(ctx, fmt.Sprintf("Updating instance details: Name: %s -> %s. Memory: %s -> %s. SecondariesCount: %v -> %v",
-			state.Name.ValueString(), plan.Name.ValueString(), state.Memory.ValueString(), plan.Memory.ValueString(),
-			state.SecondariesCount.ValueInt32(), plan.SecondariesCount.ValueInt32()))
-
-		patchRequest := client.PatchInstanceRequest{
-			Name:   plan.Name.ValueStringPointer(),
-			Memory: plan.Memory.ValueStringPointer(),
-		}
-		_, err := r.auraApi.PatchInstanceById(ctx, state.InstanceId.ValueString(), patchRequest)
-		if err != nil {
-			response.Diagnostics.AddError("Error while updating the instance details", err.Error())
-			return
+	// Regular inplace update
+	patches, changes := instanceChanges(plan, state)
+	if len(patches) > 0 {
+		attributes := make([]string, len(changes))
+		for i, change := range changes {
+			attributes[i] = change.attribute
 		}
+		tflog.Debug(ctx, fmt.Sprintf("Updating the %s of instance %s", strings.Join(attributes, ", "), state.InstanceId.ValueString()))
 
-		patchRequest = client.PatchInstanceRequest{
-			SecondariesCount: plan.SecondariesCount.ValueInt32Pointer(),
-		}
-		_, err = r.auraApi.PatchInstanceById(ctx, state.InstanceId.ValueString(), patchRequest)
-		if err != nil {
-			response.Diagnostics.AddError("Error while updating the instance details", err.Error())
-			return
+		for _, patchRequest := range patches {
+			_, err := r.auraApi.PatchInstanceById(ctx, state.InstanceId.ValueString(), patchRequest)
+			if err != nil {
+				util.AddError(&response.Diagnostics, "Error while updating the instance details", err)
+				return
+			}
 		}
 
-		_, err = r.auraApi.WaitUntilInstanceIsInState(ctx, plan.InstanceId.ValueString(), func(resp client.GetInstanceResponse) bool {
-			return resp.Data.Memory == plan.Memory.ValueString() &&
-				resp.Data.Name == plan.Name.ValueString() &&
-				(strings.ToLower(resp.Data.Status) == domain.InstanceStatusRunning || strings.ToLower(resp.Data.Status) == string(domain.InstanceStatusPaused))
+		var pending []string
+		_, err := r.auraApi.WaitUntilInstanceIsInState(ctx, plan.InstanceId.ValueString(), func(resp client.GetInstanceResponse) bool {
+			pending = pendingChanges(resp.Data, changes)
+			return len(pending) == 0
 		})
+		if err != nil && len(pending) > 0 {
+			err = fmt.Errorf("%w, not applied yet: %s", err, strings.Join(pending, ", "))
+		}
 		if err != nil {
-			response.Diagnostics.AddError("Error while waiting for the instance details to be updated", err.Error())
+			util.AddError(&response.Diagnostics, "Error while waiting for the instance details to be updated", err)
 			return
 		}
 	}
@@ -681,15 +792,99 @@ func (r *InstanceResource) Delete(ctx context.Context, request resource.DeleteRe
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Instance is protected from deletion",
+			fmt.Sprintf("Instance %s has deletion_protection set to true. Set deletion_protection to false and apply before destroying it.", data.InstanceId.ValueString()),
+		)
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts.Delete, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	switch data.OnDestroy.ValueString() {
+	case domain.InstanceOnDestroyPause:
+		r.pauseOnDestroy(ctx, data.InstanceId.ValueString(), &response.Diagnostics)
+		return
+	case domain.InstanceOnDestroySnapshotThenDelete:
+		if !r.snapshotOnDestroy(ctx, data.InstanceId.ValueString(), &response.Diagnostics) {
+			return
+		}
+	}
+
 	_, err := r.auraApi.DeleteInstanceById(ctx, data.InstanceId.ValueString())
-	// todo should we wait until instance is deleted
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Instance %s is already deleted", data.InstanceId.ValueString()))
+		return
+	}
 	if err != nil {
-		response.Diagnostics.AddError("Error while deleting an instance", err.Error())
+		util.AddError(&response.Diagnostics, "Error while deleting an instance", err)
+		return
 	}
 	err = r.auraApi.WaitUntilInstanceIsDeleted(ctx, data.InstanceId.ValueString())
 	if err != nil {
-		response.Diagnostics.AddError("Error while waiting for deleting an instance", err.Error())
+		util.AddError(&response.Diagnostics, "Error while waiting for deleting an instance", err)
+	}
+}
+
+// pauseOnDestroy pauses the instance instead of deleting it, the framework then removes it from the state
+func (r *InstanceResource) pauseOnDestroy(ctx context.Context, id string, diagnostics *diag.Diagnostics) {
+	instance, err := r.auraApi.GetInstanceById(ctx, id)
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Instance %s is already deleted", id))
+		return
+	}
+	if err != nil {
+		util.AddError(diagnostics, "Error while getting instance details", err)
+		return
 	}
+	if strings.ToLower(instance.Data.Status) == domain.InstanceStatusPaused {
+		tflog.Info(ctx, fmt.Sprintf("Instance %s is already paused", id))
+		return
+	}
+
+	diagError := r.pauseInstance(ctx, id)
+	if diagError.IsNotEmpty() {
+		diagnostics.AddError(diagError.Message, diagError.Details)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Instance %s is paused and removed from the state instead of being deleted", id))
+}
+
+// snapshotOnDestroy takes a final snapshot of the instance and waits for it to complete before the instance is
+// deleted, and reports the id of the snapshot in a warning. It returns whether the instance can be deleted.
+func (r *InstanceResource) snapshotOnDestroy(ctx context.Context, id string, diagnostics *diag.Diagnostics) bool {
+	postResponse, err := r.auraApi.PostSnapshot(ctx, id)
+	if client.IsNotFound(err) {
+		// The deletion reports the instance as already deleted
+		return true
+	}
+	if err != nil {
+		util.AddError(diagnostics, "Error while creating the final snapshot of an instance", err)
+		return false
+	}
+
+	snapshot, err := r.auraApi.WaitUntilSnapshotHasStatus(ctx, id, postResponse.Data.SnapshotId, domain.SnapshotStatusCompleted)
+	if err != nil {
+		util.AddError(diagnostics, "Error while waiting for the final snapshot of an instance", err)
+		return false
+	}
+
+	tflog.Warn(ctx, "Final snapshot of the instance is completed", map[string]interface{}{
+		"instance_id":       id,
+		"final_snapshot_id": snapshot.SnapshotId,
+		"exportable":        snapshot.Exportable,
+	})
+	diagnostics.AddWarning(
+		"Final snapshot of the instance",
+		fmt.Sprintf("Snapshot %s of instance %s was taken before deleting the instance.", snapshot.SnapshotId, id),
+	)
+	return true
 }
 
 func (r *InstanceResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
@@ -699,13 +894,11 @@ func (r *InstanceResource) ImportState(ctx context.Context, request resource.Imp
 func (r *InstanceResource) resumeInstance(ctx context.Context, id string) util.DiagnosticsError {
 	_, err := r.auraApi.ResumeInstanceById(ctx, id)
 	if err != nil {
-		return util.NewDiagnosticsError("Error while resume the instance", err.Error())
+		return util.NewErrorDiagnosticsError("Error while resume the instance", err)
 	}
-	_, err = r.auraApi.WaitUntilInstanceIsInState(ctx, id, func(resp client.GetInstanceResponse) bool {
-		return strings.ToLower(resp.Data.Status) == domain.InstanceStatusRunning
-	})
+	_, err = r.auraApi.WaitUntilInstanceHasStatus(ctx, id, domain.InstanceStatusRunning)
 	if err != nil {
-		return util.NewDiagnosticsError("Error while waiting instance to be resumed", err.Error())
+		return util.NewErrorDiagnosticsError("Error while waiting instance to be resumed", err)
 	}
 	return util.NoDiagnosticsError()
 }
@@ -713,13 +906,23 @@ func (r *InstanceResource) resumeInstance(ctx context.Context, id string) util.D
 func (r *InstanceResource) pauseInstance(ctx context.Context, id string) util.DiagnosticsError {
 	_, err := r.auraApi.PauseInstanceById(ctx, id)
 	if err != nil {
-		return util.NewDiagnosticsError("Error while pausing the instance", err.Error())
+		return util.NewErrorDiagnosticsError("Error while pausing the instance", err)
 	}
-	_, err = r.auraApi.WaitUntilInstanceIsInState(ctx, id, func(resp client.GetInstanceResponse) bool {
-		return strings.ToLower(resp.Data.Status) == domain.InstanceStatusPaused
-	})
+	_, err = r.auraApi.WaitUntilInstanceHasStatus(ctx, id, domain.InstanceStatusPaused)
 	if err != nil {
-		return util.NewDiagnosticsError("Error while waiting for instance to be paused", err.Error())
+		return util.NewErrorDiagnosticsError("Error while waiting for instance to be paused", err)
 	}
 	return util.NoDiagnosticsError()
 }
+
+// requiresReplaceIfStateIsKnown replaces the instance when the attribute changes, unless it isn't known in the state
+// because Aura doesn't return it, e.g. the version of an imported instance
+func requiresReplaceIfStateIsKnown(_ context.Context, request planmodifier.StringRequest, response *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	response.RequiresReplace = !request.StateValue.IsNull()
+}
+
+// objectRequiresReplaceIfStateIsKnown is requiresReplaceIfStateIsKnown for the object attributes, e.g. the source of
+// an imported instance
+func objectRequiresReplaceIfStateIsKnown(_ context.Context, request planmodifier.ObjectRequest, response *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	response.RequiresReplace = !request.StateValue.IsNull()
+}
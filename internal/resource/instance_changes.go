@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resource
+
+import (
+	"strings"
+
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+)
+
+// instanceChange is an attribute changed in place, with the condition the instance meets once Aura applied the change
+type instanceChange struct {
+	attribute string
+	applied   func(client.GetInstanceData) bool
+}
+
+// instanceChanges returns the PATCH requests applying the planned in-place changes, and the changes to wait for.
+// The secondaries count is sent on its own, the other attributes are sent together.
+func instanceChanges(plan InstanceResourceModel, state InstanceResourceModel) ([]client.PatchInstanceRequest, []instanceChange) {
+	var patch client.PatchInstanceRequest
+	var changes []instanceChange
+	hasPatch := false
+
+	if !plan.Name.Equal(state.Name) {
+		name := plan.Name.ValueString()
+		patch.Name = &name
+		hasPatch = true
+		changes = append(changes, instanceChange{"name", func(data client.GetInstanceData) bool {
+			return data.Name == name
+		}})
+	}
+	if !plan.Memory.Equal(state.Memory) && !plan.Memory.IsUnknown() {
+		memory := plan.Memory.ValueString()
+		patch.Memory = &memory
+		hasPatch = true
+		changes = append(changes, instanceChange{"memory", func(data client.GetInstanceData) bool {
+			return data.Memory == memory
+		}})
+	}
+	if !plan.Storage.Equal(state.Storage) && !plan.Storage.IsUnknown() && !plan.Storage.IsNull() {
+		storage := plan.Storage.ValueString()
+		patch.Storage = &storage
+		hasPatch = true
+		changes = append(changes, instanceChange{"storage", func(data client.GetInstanceData) bool {
+			return data.Storage != nil && *data.Storage == storage
+		}})
+	}
+	if !plan.CdcEnrichmentMode.Equal(state.CdcEnrichmentMode) && !plan.CdcEnrichmentMode.IsNull() {
+		cdcEnrichmentMode := plan.CdcEnrichmentMode.ValueString()
+		patch.CdcEnrichmentMode = &cdcEnrichmentMode
+		hasPatch = true
+		// Aura returns no CDC enrichment mode for business-critical instances
+		changes = append(changes, instanceChange{"cdc_enrichment_mode", func(data client.GetInstanceData) bool {
+			return data.CdcEnrichmentMode == nil || strings.EqualFold(*data.CdcEnrichmentMode, cdcEnrichmentMode)
+		}})
+	}
+	if !plan.VectorOptimized.Equal(state.VectorOptimized) && !plan.VectorOptimized.IsUnknown() && !plan.VectorOptimized.IsNull() {
+		vectorOptimized := plan.VectorOptimized.ValueBool()
+		patch.VectorOptimized = &vectorOptimized
+		hasPatch = true
+		// Aura may omit the vector optimization of the instance
+		changes = append(changes, instanceChange{"vector_optimized", func(data client.GetInstanceData) bool {
+			return data.VectorOptimized == nil || *data.VectorOptimized == vectorOptimized
+		}})
+	}
+	if !plan.GraphAnalyticsPlugin.Equal(state.GraphAnalyticsPlugin) && !plan.GraphAnalyticsPlugin.IsUnknown() && !plan.GraphAnalyticsPlugin.IsNull() {
+		graphAnalyticsPlugin := plan.GraphAnalyticsPlugin.ValueBool()
+		patch.GraphAnalyticsPlugin = &graphAnalyticsPlugin
+		hasPatch = true
+		// Aura may omit the Graph Analytics plugin of the instance
+		changes = append(changes, instanceChange{"graph_analytics_plugin", func(data client.GetInstanceData) bool {
+			return data.GraphAnalyticsPlugin == nil || *data.GraphAnalyticsPlugin == graphAnalyticsPlugin
+		}})
+	}
+
+	var patches []client.PatchInstanceRequest
+	if hasPatch {
+		patches = append(patches, patch)
+	}
+	if !plan.SecondariesCount.Equal(state.SecondariesCount) && !plan.SecondariesCount.IsNull() {
+		secondariesCount := plan.SecondariesCount.ValueInt32()
+		patches = append(patches, client.PatchInstanceRequest{SecondariesCount: &secondariesCount})
+		// Aura may omit the secondaries count of the instance
+		changes = append(changes, instanceChange{"secondaries_count", func(data client.GetInstanceData) bool {
+			return data.SecondariesCount == nil || int32(*data.SecondariesCount) == secondariesCount
+		}})
+	}
+	return patches, changes
+}
+
+// pendingChanges returns the attributes of the changes the instance doesn't reflect yet
+func pendingChanges(data client.GetInstanceData, changes []instanceChange) []string {
+	var pending []string
+	for _, change := range changes {
+		if !change.applied(data) {
+			pending = append(pending, change.attribute)
+		}
+	}
+	if status := strings.ToLower(data.Status); status != domain.InstanceStatusRunning && status != domain.InstanceStatusPaused {
+		pending = append(pending, "status")
+	}
+	return pending
+}
@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInstanceModel() InstanceResourceModel {
+	return InstanceResourceModel{
+		Name:                 types.StringValue("instance"),
+		Memory:               types.StringValue(domain.InstanceMemory4GB),
+		Storage:              types.StringValue(domain.InstanceStorage8GB),
+		CdcEnrichmentMode:    types.StringValue(domain.CdcEnrichmentModeOff),
+		VectorOptimized:      types.BoolValue(false),
+		GraphAnalyticsPlugin: types.BoolValue(false),
+		SecondariesCount:     types.Int32Null(),
+	}
+}
+
+func TestInstanceChangesWithoutChange(t *testing.T) {
+	t.Parallel()
+
+	patches, changes := instanceChanges(newInstanceModel(), newInstanceModel())
+	assert.Empty(t, patches)
+	assert.Empty(t, changes)
+}
+
+func TestInstanceChanges(t *testing.T) {
+	t.Parallel()
+
+	plan := newInstanceModel()
+	plan.Storage = types.StringValue(domain.InstanceStorage16GB)
+	plan.CdcEnrichmentMode = types.StringValue(domain.CdcEnrichmentModeFull)
+	plan.VectorOptimized = types.BoolValue(true)
+	plan.GraphAnalyticsPlugin = types.BoolValue(true)
+	plan.SecondariesCount = types.Int32Value(1)
+
+	patches, changes := instanceChanges(plan, newInstanceModel())
+	require.Len(t, patches, 2)
+	assert.Nil(t, patches[0].Name)
+	assert.Nil(t, patches[0].Memory)
+	assert.Equal(t, domain.InstanceStorage16GB, *patches[0].Storage)
+	assert.Equal(t, domain.CdcEnrichmentModeFull, *patches[0].CdcEnrichmentMode)
+	assert.True(t, *patches[0].VectorOptimized)
+	assert.True(t, *patches[0].GraphAnalyticsPlugin)
+	assert.Nil(t, patches[0].SecondariesCount)
+	assert.Equal(t, client.PatchInstanceRequest{SecondariesCount: plan.SecondariesCount.ValueInt32Pointer()}, patches[1])
+
+	storage := domain.InstanceStorage8GB
+	cdcEnrichmentMode := domain.CdcEnrichmentModeFull
+	vectorOptimized := true
+	graphAnalyticsPlugin := false
+	data := client.GetInstanceData{
+		Status:               domain.InstanceStatusUpdating,
+		Storage:              &storage,
+		CdcEnrichmentMode:    &cdcEnrichmentMode,
+		VectorOptimized:      &vectorOptimized,
+		GraphAnalyticsPlugin: &graphAnalyticsPlugin,
+	}
+	assert.Equal(t, []string{"storage", "graph_analytics_plugin", "status"}, pendingChanges(data, changes))
+
+	storage = domain.InstanceStorage16GB
+	graphAnalyticsPlugin = true
+	data.Status = domain.InstanceStatusRunning
+	assert.Empty(t, pendingChanges(data, changes))
+
+	// Like the CDC enrichment mode and the secondaries count, the plugins may be omitted by Aura
+	data.VectorOptimized = nil
+	data.GraphAnalyticsPlugin = nil
+	assert.Empty(t, pendingChanges(data, changes))
+}
+
+func TestInstanceChangesIgnoreRemovedCdcEnrichmentMode(t *testing.T) {
+	t.Parallel()
+
+	plan := newInstanceModel()
+	plan.CdcEnrichmentMode = types.StringNull()
+
+	patches, changes := instanceChanges(plan, newInstanceModel())
+	assert.Empty(t, patches)
+	assert.Empty(t, changes)
+}
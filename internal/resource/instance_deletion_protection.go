@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// protectedFromReplacement fails the plan when the modifier replaces an instance protected from deletion. The
+// framework doesn't tell the ModifyPlan of the resource which attributes require a replacement, so the check wraps
+// the modifiers that require it.
+func protectedFromReplacement(modifier planmodifier.String) planmodifier.String {
+	return protectedString{String: modifier}
+}
+
+// protectedObjectFromReplacement is protectedFromReplacement for the object attributes
+func protectedObjectFromReplacement(modifier planmodifier.Object) planmodifier.Object {
+	return protectedObject{Object: modifier}
+}
+
+type protectedString struct {
+	planmodifier.String
+}
+
+func (m protectedString) PlanModifyString(ctx context.Context, request planmodifier.StringRequest, response *planmodifier.StringResponse) {
+	m.String.PlanModifyString(ctx, request, response)
+	if response.RequiresReplace {
+		checkDeletionProtection(ctx, request.State, request.Path, &response.Diagnostics)
+	}
+}
+
+type protectedObject struct {
+	planmodifier.Object
+}
+
+func (m protectedObject) PlanModifyObject(ctx context.Context, request planmodifier.ObjectRequest, response *planmodifier.ObjectResponse) {
+	m.Object.PlanModifyObject(ctx, request, response)
+	if response.RequiresReplace {
+		checkDeletionProtection(ctx, request.State, request.Path, &response.Diagnostics)
+	}
+}
+
+func checkDeletionProtection(ctx context.Context, state tfsdk.State, attribute path.Path, diagnostics *diag.Diagnostics) {
+	var deletionProtection types.Bool
+	var instanceId types.String
+	diagnostics.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	diagnostics.Append(state.GetAttribute(ctx, path.Root("instance_id"), &instanceId)...)
+	if !deletionProtection.ValueBool() {
+		return
+	}
+
+	diagnostics.AddAttributeError(
+		attribute,
+		"Instance is protected from deletion",
+		fmt.Sprintf("Changing %s requires replacing instance %s, which has deletion_protection set to true. Set deletion_protection to false and apply before changing it.",
+			attribute, instanceId.ValueString()),
+	)
+}
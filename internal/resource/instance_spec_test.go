@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resource
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSupportedValuesMatchTheAuraSpecification fails when the domain constants diverge from the enums of the
+// OpenAPI specification the client is generated from. It is skipped while the checked-in specification is the hand
+// maintained one, marked with an x-provenance, as its enums are written from the same constants.
+func TestSupportedValuesMatchTheAuraSpecification(t *testing.T) {
+	content, err := os.ReadFile("../client/openapi/aura-v1.json")
+	require.NoError(t, err)
+	var spec struct {
+		Info struct {
+			Provenance string `json:"x-provenance"`
+		} `json:"info"`
+	}
+	require.NoError(t, json.Unmarshal(content, &spec))
+	if spec.Info.Provenance != "" {
+		t.Skip("the checked-in Aura API specification is maintained by hand: " + spec.Info.Provenance)
+	}
+
+	tests := []struct {
+		name      string
+		supported []string
+		spec      []string
+	}{
+		{name: "memory", supported: supportedMemory, spec: client.InstanceMemoryValues},
+		{name: "storage", supported: supportedStorage, spec: client.InstanceStorageValues},
+		{name: "type", supported: supportedTypes, spec: client.InstanceTypeValues},
+		{name: "cloud provider", supported: supportedCloudProviders, spec: client.CloudProviderValues},
+		{name: "version", supported: supportedVersions, spec: client.InstanceVersionValues},
+		{name: "status", supported: supportedStatuses, spec: client.InstanceStatusValues},
+		{name: "cdc enrichment mode", supported: supportedCdcEnrichmentModes, spec: client.CdcEnrichmentModeValues},
+		{
+			name:      "snapshot status",
+			supported: []string{domain.SnapshotStatusInProgress, domain.SnapshotStatusPending, domain.SnapshotStatusCompleted, domain.SnapshotStatusFailed},
+			spec:      client.SnapshotStatusValues,
+		},
+		{
+			name:      "snapshot profile",
+			supported: []string{domain.SnapshotProfileAdHoc, domain.SnapshotProfileScheduled},
+			spec:      client.SnapshotProfileValues,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ElementsMatch(t, test.spec, test.supported)
+		})
+	}
+}
@@ -62,6 +62,9 @@ var supportedStorage = []string{
 	domain.InstanceStorage2048GB,
 }
 var supportedCdcEnrichmentModes = []string{domain.CdcEnrichmentModeOff, domain.CdcEnrichmentModeDiff, domain.CdcEnrichmentModeFull}
+var supportedOnDestroy = []string{
+	domain.InstanceOnDestroyDelete, domain.InstanceOnDestroyPause, domain.InstanceOnDestroySnapshotThenDelete,
+}
 
 var (
 	_ resource.ConfigValidator = &cdcTierValidator{}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -31,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/util"
 )
 
 var (
@@ -62,6 +64,8 @@ type SnapshotResourceModel struct {
 	Profile    types.String `tfsdk:"profile"`
 	Status     types.String `tfsdk:"status"`
 	Timestamp  types.String `tfsdk:"timestamp"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SnapshotResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -85,7 +89,7 @@ func (r *SnapshotResource) Metadata(_ context.Context, request resource.Metadata
 	response.TypeName = request.ProviderTypeName + "_snapshot"
 }
 
-func (r *SnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+func (r *SnapshotResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		MarkdownDescription: "Resource for an instance snapshot",
 		Description:         "Resource for an instance snapshot",
@@ -131,6 +135,9 @@ func (r *SnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -142,18 +149,22 @@ func (r *SnapshotResource) Create(ctx context.Context, request resource.CreateRe
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts.Create, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	postResponse, err := r.auraApi.PostSnapshot(ctx, data.InstanceId.ValueString())
 	if err != nil {
-		response.Diagnostics.AddError("Error while creating a snapshot", err.Error())
+		util.AddError(&response.Diagnostics, "Error while creating a snapshot", err)
 		return
 	}
 
-	snapshot, err := r.auraApi.WaitUntilSnapshotIsInState(ctx, data.InstanceId.ValueString(), postResponse.Data.SnapshotId,
-		func(resp client.GetSnapshotData) bool {
-			return strings.ToLower(resp.Status) == "completed"
-		})
+	snapshot, err := r.auraApi.WaitUntilSnapshotHasStatus(ctx, data.InstanceId.ValueString(), postResponse.Data.SnapshotId,
+		domain.SnapshotStatusCompleted)
 	if err != nil {
-		response.Diagnostics.AddError("Error while waiting for a snapshot", err.Error())
+		util.AddError(&response.Diagnostics, "Error while waiting for a snapshot", err)
 		return
 	}
 
@@ -174,9 +185,21 @@ func (r *SnapshotResource) Read(ctx context.Context, request resource.ReadReques
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, data.Timeouts.Read, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	snapshotResponse, err := r.auraApi.GetSnapshotById(ctx, data.InstanceId.ValueString(), data.SnapshotId.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Snapshot %s of instance %s no longer exists, removing it from state",
+			data.SnapshotId.ValueString(), data.InstanceId.ValueString()))
+		response.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		response.Diagnostics.AddError("Error reading snapshot", err.Error())
+		util.AddError(&response.Diagnostics, "Error reading snapshot", err)
 		return
 	}
 
@@ -187,8 +210,15 @@ func (r *SnapshotResource) Read(ctx context.Context, request resource.ReadReques
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
-func (r *SnapshotResource) Update(ctx context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
-	tflog.Info(ctx, "Snapshot resources are immutable and cannot be updated")
+// Update only applies changes of the timeouts, as the other attributes of a snapshot are immutable
+func (r *SnapshotResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	tflog.Info(ctx, "Updating the timeouts of the snapshot, its other attributes are immutable")
+	var timeoutsValue timeouts.Value
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("timeouts"), timeoutsValue)...)
 }
 
 func (r *SnapshotResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
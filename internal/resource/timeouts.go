@@ -0,0 +1,41 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// withOperationTimeout bounds ctx with the timeout of the operation when it is set in the timeouts block, so that
+// it becomes the deadline of every wait of the operation. Otherwise, each wait is bounded by the instance_timeout
+// or snapshot_timeout of the provider.
+func withOperationTimeout(
+	ctx context.Context,
+	timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics),
+	diagnostics *diag.Diagnostics) (context.Context, context.CancelFunc) {
+
+	duration, diags := timeout(ctx, 0)
+	diagnostics.Append(diags...)
+	if diags.HasError() || duration <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, duration)
+}
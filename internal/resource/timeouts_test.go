@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTimeouts(t *testing.T, create string) timeouts.Value {
+	attributeTypes := map[string]attr.Type{
+		"create": types.StringType, "read": types.StringType, "update": types.StringType, "delete": types.StringType,
+	}
+	createValue := types.StringNull()
+	if create != "" {
+		createValue = types.StringValue(create)
+	}
+	object, diags := types.ObjectValue(attributeTypes, map[string]attr.Value{
+		"create": createValue, "read": types.StringNull(), "update": types.StringNull(), "delete": types.StringNull(),
+	})
+	require.False(t, diags.HasError())
+	return timeouts.Value{Object: object}
+}
+
+func TestOperationTimeoutSetsTheDeadline(t *testing.T) {
+	t.Parallel()
+
+	var diagnostics diag.Diagnostics
+	ctx, cancel := withOperationTimeout(context.Background(), newTimeouts(t, "90m").Create, &diagnostics)
+	defer cancel()
+
+	require.False(t, diagnostics.HasError())
+	deadline, hasDeadline := ctx.Deadline()
+	require.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(90*time.Minute), deadline, time.Minute)
+}
+
+func TestOperationTimeoutDefaultsToTheProviderTimeouts(t *testing.T) {
+	t.Parallel()
+
+	for name, value := range map[string]timeouts.Value{
+		"unset":    newTimeouts(t, ""),
+		"no block": {Object: types.ObjectNull(newTimeouts(t, "").AttributeTypes(context.Background()))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			ctx, cancel := withOperationTimeout(context.Background(), value.Create, &diagnostics)
+			defer cancel()
+
+			assert.False(t, diagnostics.HasError())
+			_, hasDeadline := ctx.Deadline()
+			assert.False(t, hasDeadline)
+		})
+	}
+}
+
+func TestOperationTimeoutRejectsInvalidDurations(t *testing.T) {
+	t.Parallel()
+
+	var diagnostics diag.Diagnostics
+	_, cancel := withOperationTimeout(context.Background(), newTimeouts(t, "soon").Create, &diagnostics)
+	defer cancel()
+
+	assert.True(t, diagnostics.HasError())
+}
@@ -28,6 +28,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+// envFakeApi runs the acceptance tests against an in-process fake Aura API instead of the real one
+const envFakeApi = "NEO4J_AURA_FAKE_API"
+
 var uuidRegex = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
 
 func nonEmptyString(s string) error {
@@ -63,3 +66,19 @@ func SkipIfNotAcceptance(t *testing.T) {
 		return
 	}
 }
+
+// SkipIfFakeApi skips tests that need a real database, e.g. to run Cypher queries
+func SkipIfFakeApi(t *testing.T) {
+	if os.Getenv(envFakeApi) != "" {
+		t.Skip(fmt.Sprintf("Test skipped when running against the fake Aura API ('%s' set)", envFakeApi))
+		return
+	}
+}
+
+// SkipIfNotFakeApi skips tests that script the responses of the fake Aura API, e.g. to make an instance slow to start
+func SkipIfNotFakeApi(t *testing.T) {
+	if os.Getenv(envFakeApi) == "" {
+		t.Skip(fmt.Sprintf("Test skipped unless running against the fake Aura API ('%s' set)", envFakeApi))
+		return
+	}
+}
@@ -21,12 +21,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/domain"
@@ -93,6 +97,7 @@ resource "neo4jaura_instance" "this" {
 `, defaultProviderConfig)
 
 func TestAcc_can_create_instance_resource(t *testing.T) {
+	SkipIfFakeApi(t)
 	connectionUrlCapturer := &Capturer[string]{}
 	usernameCapturer := &Capturer[string]{}
 	passwordCapturer := &Capturer[string]{}
@@ -256,10 +261,381 @@ func TestAcc_secondaries_count_no_drift(t *testing.T) {
 	})
 }
 
+func TestAcc_instance_deleted_outside_of_terraform_is_recreated(t *testing.T) {
+	SkipIfNotAcceptance(t)
+	t.Parallel()
+
+	api := newTestAuraApi(t)
+	instanceIdCapturer := &Capturer[string]{}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: professionalTierInstanceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"neo4jaura_instance.this",
+						tfjsonpath.New("instance_id"),
+						knownvalue.StringFunc(instanceIdCapturer.Capture(nonEmptyString)),
+					),
+				},
+			},
+			{
+				// Delete the instance behind Terraform's back
+				PreConfig: func() {
+					ctx := context.Background()
+					_, err := api.DeleteInstanceById(ctx, instanceIdCapturer.Value)
+					require.NoError(t, err)
+					require.NoError(t, api.WaitUntilInstanceIsDeleted(ctx, instanceIdCapturer.Value))
+				},
+				Config:             professionalTierInstanceConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// instanceConfig returns the configuration of an instance, with extraConfig appended to the body of the resource, e.g.
+// optional attributes or a timeouts block
+func instanceConfig(name, cloudProvider, region, memory, instanceType, extraConfig string) string {
+	return fmt.Sprintf(`
+%[1]s
+data "neo4jaura_projects" "this" {}
+
+resource "neo4jaura_instance" "this" {
+  name           = %[2]q
+  cloud_provider = %[3]q
+  region         = %[4]q
+  memory         = %[5]q
+  type           = %[6]q
+  project_id     = data.neo4jaura_projects.this.projects.0.id%[7]s
+}
+`, defaultProviderConfig, name, cloudProvider, region, memory, instanceType, extraConfig)
+}
+
+func TestAcc_immutable_instance_attributes_force_replacement(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: instanceConfig("TestReplacement", "gcp", "europe-west1", "1GB", "professional-db", ""),
+			},
+			{
+				Config: instanceConfig("TestReplacement", "gcp", "europe-west2", "1GB", "professional-db", ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionReplace),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"neo4jaura_instance.this",
+						tfjsonpath.New("region"),
+						knownvalue.StringExact("europe-west2"),
+					),
+				},
+			},
+			{
+				Config: instanceConfig("TestReplacement", "aws", "eu-west-2", "1GB", "professional-db", ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			{
+				// The name is updated in place
+				Config: instanceConfig("TestReplacementRenamed", "aws", "eu-west-2", "1GB", "professional-db", ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_storage_and_plugins_are_updated_in_place(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: instanceConfig("TestInPlaceOptions", "gcp", "europe-west1", "4GB", "professional-db", `
+  storage                = "8GB"
+  vector_optimized       = false
+  graph_analytics_plugin = false`),
+			},
+			{
+				Config: instanceConfig("TestInPlaceOptions", "gcp", "europe-west1", "4GB", "professional-db", `
+  storage                = "16GB"
+  vector_optimized       = true
+  graph_analytics_plugin = true`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("neo4jaura_instance.this", tfjsonpath.New("storage"), knownvalue.StringExact("16GB")),
+					statecheck.ExpectKnownValue("neo4jaura_instance.this", tfjsonpath.New("vector_optimized"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("neo4jaura_instance.this", tfjsonpath.New("graph_analytics_plugin"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				// The refreshed instance reflects the changes
+				Config: instanceConfig("TestInPlaceOptions", "gcp", "europe-west1", "4GB", "professional-db", `
+  storage                = "16GB"
+  vector_optimized       = true
+  graph_analytics_plugin = true`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_cdc_enrichment_mode_is_updated_in_place(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: instanceConfig("TestInPlaceCdc", "gcp", "us-central1", "8GB", "business-critical", `
+  cdc_enrichment_mode = "OFF"`),
+			},
+			{
+				Config: instanceConfig("TestInPlaceCdc", "gcp", "us-central1", "8GB", "business-critical", `
+  cdc_enrichment_mode = "FULL"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("neo4jaura_instance.this", tfjsonpath.New("cdc_enrichment_mode"), knownvalue.StringExact("FULL")),
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_instance_timeouts(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: instanceConfig("TestTimeouts", "gcp", "europe-west1", "1GB", "professional-db", `
+  timeouts {
+    create = "20m"
+    update = "10m"
+  }`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("neo4jaura_instance.this", tfjsonpath.New("timeouts").AtMapKey("create"), knownvalue.StringExact("20m")),
+				},
+			},
+			{
+				// Changing a timeout doesn't replace the instance
+				Config: instanceConfig("TestTimeouts", "gcp", "europe-west1", "1GB", "professional-db", `
+  timeouts {
+    create = "20m"
+    update = "30m"
+  }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("neo4jaura_instance.this", tfjsonpath.New("timeouts").AtMapKey("update"), knownvalue.StringExact("30m")),
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_instance_not_running_in_time_is_replaced(t *testing.T) {
+	SkipIfNotFakeApi(t)
+	t.Parallel()
+
+	config := instanceConfig("TestCreationTimeout", "gcp", "us-central1", "8GB", "business-critical", `
+  cdc_enrichment_mode = "FULL"
+
+  timeouts {
+    create = "2s"
+  }`)
+	statuses := slices.Repeat([]string{domain.InstanceStatusCreating}, 10)
+	fakeServer.ScriptCreationStatuses("TestCreationTimeout", append(statuses, domain.InstanceStatusRunning)...)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("Instance is not running in time"),
+			},
+			{
+				// The instance created by the failed apply is in the state, so it's replaced instead of leaked
+				PreConfig: func() {
+					fakeServer.ScriptCreationStatuses("TestCreationTimeout", domain.InstanceStatusRunning)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_deletion_protection(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: instanceConfig("TestDeletionProtection", "gcp", "europe-west1", "1GB", "professional-db", `
+  deletion_protection = true`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("neo4jaura_instance.this", tfjsonpath.New("deletion_protection"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				// A replacement is refused at plan time
+				Config: instanceConfig("TestDeletionProtection", "gcp", "europe-west2", "1GB", "professional-db", `
+  deletion_protection = true`),
+				ExpectError: regexp.MustCompile(`(?s)Instance is protected from deletion.*Changing region requires replacing`),
+			},
+			{
+				// Destroying the instance is refused
+				Config: fmt.Sprintf(`
+%[1]s
+data "neo4jaura_projects" "this" {}
+`, defaultProviderConfig),
+				ExpectError: regexp.MustCompile(`(?s)Instance is protected from deletion.*deletion_protection set to true`),
+			},
+			{
+				// The instance can be destroyed once it is no longer protected
+				Config: instanceConfig("TestDeletionProtection", "gcp", "europe-west1", "1GB", "professional-db", `
+  deletion_protection = false`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_on_destroy(t *testing.T) {
+	SkipIfNotAcceptance(t)
+	t.Parallel()
+
+	api := newTestAuraApi(t)
+	withoutInstanceConfig := fmt.Sprintf(`
+%[1]s
+data "neo4jaura_projects" "this" {}
+`, defaultProviderConfig)
+
+	t.Run("pause", func(t *testing.T) {
+		t.Parallel()
+
+		instanceIdCapturer := &Capturer[string]{}
+		t.Cleanup(func() {
+			if instanceIdCapturer.Value != "" {
+				_, _ = api.DeleteInstanceById(context.Background(), instanceIdCapturer.Value)
+			}
+		})
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: instanceConfig("TestOnDestroy", "gcp", "europe-west1", "1GB", "professional-db", `
+  on_destroy = "pause"`),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue(
+							"neo4jaura_instance.this",
+							tfjsonpath.New("instance_id"),
+							knownvalue.StringFunc(instanceIdCapturer.Capture(nonEmptyString)),
+						),
+					},
+				},
+				{
+					// The instance is paused and removed from the state
+					Config: withoutInstanceConfig,
+					Check: func(_ *terraform.State) error {
+						instance, err := api.GetInstanceById(context.Background(), instanceIdCapturer.Value)
+						if err != nil {
+							return err
+						}
+						if instance.Data.Status != domain.InstanceStatusPaused {
+							return fmt.Errorf("expected instance %s to be paused, got %s", instanceIdCapturer.Value, instance.Data.Status)
+						}
+						return nil
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("snapshot_then_delete", func(t *testing.T) {
+		t.Parallel()
+
+		instanceIdCapturer := &Capturer[string]{}
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: instanceConfig("TestOnDestroy", "gcp", "europe-west1", "1GB", "professional-db", `
+  on_destroy = "snapshot_then_delete"`),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue(
+							"neo4jaura_instance.this",
+							tfjsonpath.New("instance_id"),
+							knownvalue.StringFunc(instanceIdCapturer.Capture(nonEmptyString)),
+						),
+					},
+				},
+				{
+					// The instance is deleted after its final snapshot
+					Config: withoutInstanceConfig,
+					Check: func(_ *terraform.State) error {
+						_, err := api.GetInstanceById(context.Background(), instanceIdCapturer.Value)
+						if !client.IsNotFound(err) {
+							return fmt.Errorf("expected instance %s to be deleted, got %v", instanceIdCapturer.Value, err)
+						}
+						// Aura deletes the snapshots with the instance, the fake API still counts the one taken
+						snapshots := "POST /v1/instances/" + instanceIdCapturer.Value + "/snapshots"
+						if fakeServer != nil && fakeServer.Requests(snapshots) != 1 {
+							return fmt.Errorf("expected a final snapshot of instance %s, got %d", instanceIdCapturer.Value, fakeServer.Requests(snapshots))
+						}
+						return nil
+					},
+				},
+			},
+		})
+	})
+}
+
 func TestAcc_can_import_instance_resource(t *testing.T) {
 	SkipIfNotAcceptance(t)
 
-	api := newTestAuraApi()
+	api := newTestAuraApi(t)
 	examples := []struct {
 		name               string
 		createResourceFunc func(*testing.T) string
@@ -270,6 +646,7 @@ func TestAcc_can_import_instance_resource(t *testing.T) {
 		{
 			name: "free tier",
 			createResourceFunc: func(tt *testing.T) string {
+				SkipIfFakeApi(tt)
 				ctx := context.Background()
 				instance, err := api.PostInstance(ctx, client.PostInstanceRequest{
 					Version:       domain.InstanceVersion5,
@@ -451,3 +828,64 @@ func TestAcc_can_import_instance_resource(t *testing.T) {
 		})
 	}
 }
+
+func TestAcc_imported_instance_with_source_is_not_replaced(t *testing.T) {
+	SkipIfNotAcceptance(t)
+	t.Parallel()
+
+	api := newTestAuraApi(t)
+	ctx := context.Background()
+	source, err := api.PostInstance(ctx, client.PostInstanceRequest{
+		Version:       domain.InstanceVersion5,
+		Name:          "TestImportSource",
+		CloudProvider: domain.CloudProviderGcp,
+		Region:        "europe-west1",
+		Memory:        domain.InstanceMemory1GB,
+		Type:          domain.InstanceTypeProfessionalDb,
+		TenantId:      os.Getenv("AURA_PROJECT_ID"),
+	})
+	require.NoError(t, err)
+	defer api.DeleteInstanceById(context.Background(), source.Data.Id)
+	clone, err := api.PostInstance(ctx, client.PostInstanceRequest{
+		Version:          domain.InstanceVersion5,
+		Name:             "MyTestProfessionalInstance",
+		CloudProvider:    domain.CloudProviderGcp,
+		Region:           "europe-west1",
+		Memory:           domain.InstanceMemory1GB,
+		Type:             domain.InstanceTypeProfessionalDb,
+		TenantId:         os.Getenv("AURA_PROJECT_ID"),
+		SourceInstanceId: &source.Data.Id,
+	})
+	require.NoError(t, err)
+	defer api.DeleteInstanceById(context.Background(), clone.Data.Id)
+	_, err = api.WaitUntilInstanceIsInState(ctx, clone.Data.Id, func(r client.GetInstanceResponse) bool {
+		return r.Data.Status == domain.InstanceStatusRunning
+	})
+	require.NoError(t, err)
+
+	// The source isn't returned by Aura, so it's null in the imported state
+	config := instanceConfig("MyTestProfessionalInstance", "gcp", "europe-west1", "1GB", "professional-db", fmt.Sprintf(`
+  source = {
+    instance_id = %q
+  }`, source.Data.Id))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ResourceName:       "neo4jaura_instance.this",
+				ImportState:        true,
+				ImportStateId:      clone.Data.Id,
+				ImportStatePersist: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neo4jaura_instance.this", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+		},
+	})
+}
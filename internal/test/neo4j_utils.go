@@ -20,6 +20,7 @@ package test
 import (
 	"context"
 	"os"
+	"testing"
 
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -45,11 +46,15 @@ func executeCypher(ctx context.Context, connectionUrl, username, password string
 	return err
 }
 
-func newTestAuraApi() *client.AuraApi {
-	return client.NewAuraApi(
-		client.NewAuraClient(
-			os.Getenv("TF_VAR_client_id"),
-			os.Getenv("TF_VAR_client_secret"),
-			"0.0.0-tests"),
-		nil, nil)
+func newTestAuraApi(t *testing.T) *client.AuraApi {
+	auraClient, err := client.NewAuraClient(client.AuraClientConfig{
+		ClientId:     os.Getenv("TF_VAR_client_id"),
+		ClientSecret: os.Getenv("TF_VAR_client_secret"),
+		BaseUrl:      os.Getenv("NEO4J_AURA_BASE_URL"),
+		Version:      "0.0.0-tests",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.NewAuraApi(auraClient, nil, nil)
 }
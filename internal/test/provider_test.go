@@ -18,13 +18,18 @@
 package test
 
 import (
+	"os"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/fakeaura"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/provider"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"neo4jaura": providerserver.NewProtocol6WithError(provider.New("test")()),
+	"neo4jaura": providerserver.NewProtocol6WithError(provider.New("test", client.NewTelemetry())()),
 }
 
 const defaultProviderConfig = `
@@ -36,3 +41,23 @@ provider "neo4jaura" {
 variable "client_id" {}
 variable "client_secret" {}
 `
+
+// fakeServer is the fake Aura API the acceptance tests run against, when envFakeApi is set
+var fakeServer *fakeaura.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv(envFakeApi) == "" {
+		os.Exit(m.Run())
+	}
+
+	server := fakeaura.NewServer()
+	fakeServer = server
+	os.Setenv("NEO4J_AURA_BASE_URL", server.URL())
+	os.Setenv("TF_VAR_client_id", fakeaura.DefaultClientId)
+	os.Setenv("TF_VAR_client_secret", fakeaura.DefaultClientSecret)
+	os.Setenv("AURA_PROJECT_ID", fakeaura.DefaultTenantId)
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
@@ -55,7 +55,7 @@ resource "neo4jaura_instance" "this" {
 resource "neo4jaura_snapshot" "this" {}
 `, testInstanceConfig)
 
-	api := newTestAuraApi()
+	api := newTestAuraApi(t)
 
 	instanceIdCapturer := &Capturer[string]{}
 	var snapshotId string
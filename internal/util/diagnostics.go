@@ -0,0 +1,48 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package util
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// diagnosticSummarizer is implemented by errors that need a dedicated diagnostic summary,
+// whatever the operation that failed
+type diagnosticSummarizer interface {
+	DiagnosticSummary() string
+}
+
+// AddError adds an error diagnostic for err, using its own summary when it has one
+func AddError(diagnostics *diag.Diagnostics, summary string, err error) {
+	diagnostics.AddError(summaryOf(err, summary), err.Error())
+}
+
+// NewErrorDiagnosticsError returns the DiagnosticsError for err, using its own summary when it has one
+func NewErrorDiagnosticsError(summary string, err error) DiagnosticsError {
+	return NewDiagnosticsError(summaryOf(err, summary), err.Error())
+}
+
+func summaryOf(err error, summary string) string {
+	var summarizer diagnosticSummarizer
+	if errors.As(err, &summarizer) {
+		return summarizer.DiagnosticSummary()
+	}
+	return summary
+}
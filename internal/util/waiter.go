@@ -18,20 +18,144 @@
 package util
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/rand/v2"
 	"time"
 )
 
-func WaitUntil[T any](get func() (T, error), condition func(T, error) bool, delay time.Duration, maxWaitTime time.Duration) (T, error) {
-	end := time.Now().Add(maxWaitTime)
+const (
+	defaultWaitMultiplier = 1.5
+	waitJitter            = 0.2
+)
+
+// WaitOptions configures the polling of WaitUntil
+type WaitOptions struct {
+	// InitialInterval is the delay before the first retry
+	InitialInterval time.Duration
+	// MaxInterval bounds the delay between two attempts
+	MaxInterval time.Duration
+	// Multiplier grows the delay after every attempt. Defaults to 1.5
+	Multiplier float64
+	// Timeout bounds the whole wait. It is ignored when the context already has a deadline
+	Timeout time.Duration
+}
+
+// WaitTimeoutError is returned when the waiting condition isn't reached before the deadline
+type WaitTimeoutError struct {
+	Timeout   time.Duration
+	LastValue any
+	LastErr   error
+}
+
+func (e *WaitTimeoutError) Error() string {
+	message := "waiting condition wasn't reached in time"
+	if e.Timeout > 0 {
+		message = fmt.Sprintf("waiting condition wasn't reached in %s", e.Timeout)
+	}
+	if e.LastValue != nil {
+		message += ". Last observed value: " + describe(e.LastValue)
+	}
+	if e.LastErr != nil {
+		message += ". Last error: " + e.LastErr.Error()
+	}
+	return message
+}
+
+func (e *WaitTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+type stopWaitingError struct {
+	err error
+}
+
+func (e *stopWaitingError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stopWaitingError) Unwrap() error {
+	return e.err
+}
+
+// StopWaiting wraps an error returned by the get function of WaitUntil, to abort waiting
+// immediately, e.g. when the condition can no longer be reached
+func StopWaiting(err error) error {
+	return &stopWaitingError{err: err}
+}
+
+// WaitUntil polls get until condition is satisfied, with an exponential backoff and jitter between attempts.
+// It returns promptly when ctx is cancelled or its deadline is exceeded.
+func WaitUntil[T any](ctx context.Context, get func(context.Context) (T, error), condition func(T, error) bool, options WaitOptions) (T, error) {
+	timeout := options.Timeout
+	if deadline, hasDeadline := ctx.Deadline(); hasDeadline {
+		timeout = time.Until(deadline).Round(time.Second)
+	} else if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	multiplier := options.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultWaitMultiplier
+	}
+	interval := options.InitialInterval
+
+	var lastValue *T
+	var lastErr error
 	for {
-		res, err := get()
+		res, err := get(ctx)
 		if condition(res, err) {
 			return res, nil
 		}
-		if time.Now().After(end) {
-			return res, errors.New("waiting condition wasn't reached in time")
+		var stop *stopWaitingError
+		if errors.As(err, &stop) {
+			return res, stop.err
+		}
+		if err == nil {
+			lastValue = &res
+		}
+		lastErr = err
+
+		timer := time.NewTimer(jittered(interval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, waitError(ctx, timeout, lastValue, lastErr)
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * multiplier)
+		if options.MaxInterval > 0 && interval > options.MaxInterval {
+			interval = options.MaxInterval
 		}
-		time.Sleep(delay)
 	}
 }
+
+func waitError[T any](ctx context.Context, timeout time.Duration, lastValue *T, lastErr error) error {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("waiting was cancelled: %w", ctx.Err())
+	}
+	timeoutError := &WaitTimeoutError{Timeout: timeout}
+	if lastValue != nil {
+		timeoutError.LastValue = *lastValue
+	}
+	if lastErr != nil && !errors.Is(lastErr, context.DeadlineExceeded) {
+		timeoutError.LastErr = lastErr
+	}
+	return timeoutError
+}
+
+func jittered(interval time.Duration) time.Duration {
+	return time.Duration(float64(interval) * (1 - waitJitter + 2*waitJitter*rand.Float64()))
+}
+
+func describe(value any) string {
+	if encoded, err := json.Marshal(value); err == nil {
+		return string(encoded)
+	}
+	return fmt.Sprintf("%+v", value)
+}
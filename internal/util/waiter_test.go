@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) "Neo4j"
+ *  Neo4j Sweden AB [https://neo4j.com]
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type status struct {
+	Status string `json:"status"`
+}
+
+func TestWaitUntilReachesCondition(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	res, err := WaitUntil(context.Background(),
+		func(context.Context) (int, error) {
+			calls++
+			return calls, nil
+		},
+		func(value int, err error) bool {
+			return err == nil && value == 3
+		},
+		WaitOptions{InitialInterval: time.Millisecond, Timeout: time.Second},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res)
+}
+
+func TestWaitUntilTimeoutReportsLastValue(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	_, err := WaitUntil(context.Background(),
+		func(context.Context) (status, error) {
+			calls++
+			if calls > 1 {
+				return status{}, errors.New("boom")
+			}
+			return status{Status: "creating"}, nil
+		},
+		func(status, error) bool {
+			return false
+		},
+		WaitOptions{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Timeout: 50 * time.Millisecond},
+	)
+
+	var timeoutError *WaitTimeoutError
+	require.ErrorAs(t, err, &timeoutError)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, status{Status: "creating"}, timeoutError.LastValue)
+	assert.Contains(t, err.Error(), `Last observed value: {"status":"creating"}`)
+	assert.Contains(t, err.Error(), "Last error: boom")
+}
+
+func TestWaitUntilReturnsPromptlyOnCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := WaitUntil(ctx,
+		func(context.Context) (int, error) {
+			return 0, nil
+		},
+		func(int, error) bool {
+			return false
+		},
+		WaitOptions{InitialInterval: time.Minute, Timeout: time.Hour},
+	)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWaitUntilUsesContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := WaitUntil(ctx,
+		func(context.Context) (int, error) {
+			return 0, nil
+		},
+		func(int, error) bool {
+			return false
+		},
+		WaitOptions{InitialInterval: time.Millisecond, Timeout: time.Hour},
+	)
+	var timeoutError *WaitTimeoutError
+	assert.ErrorAs(t, err, &timeoutError)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
+	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/client"
 	"github.com/neo4j-labs/terraform-provider-neo4jaura/internal/provider"
 )
 
@@ -24,7 +26,17 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	telemetry := client.NewTelemetry()
+	err := providerserver.Serve(context.Background(), provider.New(version, telemetry), opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := telemetry.Shutdown(ctx); shutdownErr != nil {
+		log.Printf("[WARN] Cannot export the remaining telemetry: %s", shutdownErr)
+	}
+	if closeErr := client.CloseCaptureFiles(); closeErr != nil {
+		log.Printf("[WARN] Cannot close the HTTP capture file: %s", closeErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())